@@ -5,8 +5,9 @@ go 1.12
 require (
 	github.com/Masterminds/semver v1.5.0
 	github.com/Masterminds/sprig v2.22.0+incompatible
-	github.com/PaesslerAG/gval v1.0.1 // indirect
+	github.com/PaesslerAG/gval v1.0.1
 	github.com/PaesslerAG/jsonpath v0.1.0
+	github.com/aws/aws-sdk-go v1.15.78
 	github.com/creasty/defaults v1.3.0 // indirect
 	github.com/evanphx/json-patch v4.5.0+incompatible
 	github.com/go-logr/logr v0.1.0
@@ -18,6 +19,7 @@ require (
 	github.com/heroku/docker-registry-client v0.0.0-20190909225348-afc9e1acc3d5
 	github.com/iancoleman/strcase v0.0.0-20190422225806-e506e3ef7365 // indirect
 	github.com/imdario/mergo v0.3.8 // indirect
+	github.com/itchyny/gojq v0.6.0
 	github.com/k-kinzal/aliases v0.5.1
 	github.com/kylelemons/godebug v1.1.0
 	github.com/spf13/cobra v0.0.5
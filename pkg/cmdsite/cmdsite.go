@@ -2,6 +2,8 @@ package cmdsite
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"k8s.io/klog"
 	"os"
@@ -11,9 +13,16 @@ import (
 
 type RunCommand func(name string, args []string, stdout, stderr io.Writer, env map[string]string) error
 
+// RunCommandContext is RunCommand that kills the command once the context is done
+type RunCommandContext func(ctx context.Context, name string, args []string, stdout, stderr io.Writer, env map[string]string) error
+
 type CommandSite struct {
 	RunCmd RunCommand
 
+	// RunCmdContext is used by CaptureStringsContext and CaptureBytesContext when set.
+	// Otherwise, RunCmd is run in the background and abandoned once the context is done.
+	RunCmdContext RunCommandContext
+
 	Env map[string]string
 }
 
@@ -35,6 +44,20 @@ func (o *runcmdOption) Set(site *CommandSite) {
 	site.RunCmd = o.runcmd
 }
 
+func RunCmdContext(runcmd RunCommandContext) Option {
+	return &runcmdContextOption{
+		runcmd: runcmd,
+	}
+}
+
+type runcmdContextOption struct {
+	runcmd RunCommandContext
+}
+
+func (o *runcmdContextOption) Set(site *CommandSite) {
+	site.RunCmdContext = o.runcmd
+}
+
 func New(opt ...Option) *CommandSite {
 	site := &CommandSite{
 		RunCmd: nil,
@@ -83,6 +106,67 @@ func (r *CommandSite) CaptureBytes(binary string, args []string) ([]byte, []byte
 	return stdout.Bytes(), stderr.Bytes(), err
 }
 
+// CaptureStringsContext is CaptureStrings that returns the context's error as soon as the context is done
+func (r *CommandSite) CaptureStringsContext(ctx context.Context, binary string, args []string) (string, string, error) {
+	stdout, stderr, err := r.CaptureBytesContext(ctx, binary, args)
+
+	var so, se string
+
+	if stdout != nil {
+		so = string(stdout)
+	}
+
+	if stderr != nil {
+		se = string(stderr)
+	}
+
+	return so, se, err
+}
+
+// CaptureBytesContext is CaptureBytes that returns the context's error as soon as the context is done
+func (r *CommandSite) CaptureBytesContext(ctx context.Context, binary string, args []string) ([]byte, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if r.RunCmdContext == nil {
+		type result struct {
+			stdout, stderr []byte
+			err            error
+		}
+
+		ch := make(chan result, 1)
+
+		go func() {
+			stdout, stderr, err := r.CaptureBytes(binary, args)
+			ch <- result{stdout: stdout, stderr: stderr, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("running %s: %w", binary, ctx.Err())
+		case res := <-ch:
+			return res.stdout, res.stderr, res.err
+		}
+	}
+
+	klog.V(1).Infof("running %s %s", binary, strings.Join(args, " "))
+	_, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = r.RunCmdContext(ctx, binary, args, &stdout, &stderr, r.Env)
+	if err != nil {
+		klog.V(1).Info(stderr.String())
+		if ctx.Err() != nil {
+			return stdout.Bytes(), stderr.Bytes(), fmt.Errorf("running %s: %w", binary, ctx.Err())
+		}
+	}
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
 func (r *CommandSite) SetPath(path string) *CommandSite {
 	runCmd := DefaultRunCommand
 	if r.RunCmd != nil {
@@ -98,6 +182,30 @@ func (r *CommandSite) SetPath(path string) *CommandSite {
 		newenv["PATH"] = path
 		return runCmd(cmd, args, stdout, stderr, newenv)
 	}
+	if r.RunCmdContext != nil {
+		runCmdContext := r.RunCmdContext
+		site.RunCmdContext = func(ctx context.Context, cmd string, args []string, stdout io.Writer, stderr io.Writer, env map[string]string) error {
+			newenv := map[string]string{}
+			for k, v := range env {
+				newenv[k] = v
+			}
+			newenv["PATH"] = path
+			return runCmdContext(ctx, cmd, args, stdout, stderr, newenv)
+		}
+	}
+	return &site
+}
+
+// SetEnv returns the copy of the site whose commands are run with the envvars added to Env
+func (r *CommandSite) SetEnv(env map[string]string) *CommandSite {
+	site := *r
+	site.Env = map[string]string{}
+	for k, v := range r.Env {
+		site.Env[k] = v
+	}
+	for k, v := range env {
+		site.Env[k] = v
+	}
 	return &site
 }
 
@@ -1,18 +1,146 @@
 package cmdsite
 
 import (
+	"context"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 )
 
 func DefaultRunCommand(cmd string, args []string, stdout, stderr io.Writer, env map[string]string) error {
-	command := exec.Command(cmd, args...)
-	command.Stdout = stdout
-	command.Stderr = stderr
-	command.Env = mergeEnv(os.Environ(), env)
-	return command.Run()
+	return DefaultRunCommandIn("")(cmd, args, stdout, stderr, env)
+}
+
+// DefaultRunCommandIn returns DefaultRunCommand that runs the command in the directory.
+// An empty dir runs the command in the current directory.
+func DefaultRunCommandIn(dir string) RunCommand {
+	return func(cmd string, args []string, stdout, stderr io.Writer, env map[string]string) error {
+		command := exec.Command(cmd, args...)
+		command.Dir = dir
+		command.Stdout = stdout
+		command.Stderr = stderr
+		command.Env = mergeEnv(os.Environ(), env)
+		return command.Run()
+	}
+}
+
+// DefaultWaitDelay is how long DefaultRunCommandContext waits for the output of a killed command to be closed.
+// Children of the command, like the ones of a `sh -c` script, may keep the output open after the command is killed.
+const DefaultWaitDelay = time.Second
+
+// DefaultRunCommandContext is DefaultRunCommand that kills the command once the context is done
+func DefaultRunCommandContext(ctx context.Context, cmd string, args []string, stdout, stderr io.Writer, env map[string]string) error {
+	return DefaultRunCommandContextIn("")(ctx, cmd, args, stdout, stderr, env)
+}
+
+// DefaultRunCommandContextIn returns DefaultRunCommandContext that runs the command in the directory.
+// The command is run in its own process group, which is killed as a whole once the context is done,
+// and the output is closed after DefaultWaitDelay in case a child outside the group still holds it.
+func DefaultRunCommandContextIn(dir string) RunCommandContext {
+	return func(ctx context.Context, cmd string, args []string, stdout, stderr io.Writer, env map[string]string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		command := exec.Command(cmd, args...)
+		command.Dir = dir
+		command.Env = mergeEnv(os.Environ(), env)
+		setProcessGroup(command)
+
+		outR, outW, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+
+		errR, errW, err := os.Pipe()
+		if err != nil {
+			outR.Close()
+			outW.Close()
+			return err
+		}
+
+		command.Stdout = outW
+		command.Stderr = errW
+
+		err = command.Start()
+
+		// The command has its own copies of the write ends, which are closed once it and its children exit
+		outW.Close()
+		errW.Close()
+
+		if err != nil {
+			outR.Close()
+			errR.Close()
+			return err
+		}
+
+		copied := copyOutputs(outR, stdout, errR, stderr)
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- command.Wait()
+		}()
+
+		var runErr error
+
+		select {
+		case runErr = <-exited:
+		case <-ctx.Done():
+			killProcessGroup(command)
+			runErr = <-exited
+		}
+
+		select {
+		case <-copied:
+		case <-ctx.Done():
+			timer := time.NewTimer(DefaultWaitDelay)
+			select {
+			case <-copied:
+			case <-timer.C:
+			}
+			timer.Stop()
+		}
+
+		// Unblocks the copies when a child still holds the output
+		outR.Close()
+		errR.Close()
+		<-copied
+
+		if runErr == nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return runErr
+	}
+}
+
+// copyOutputs copies the outputs of the command to the writers in the background. The channel is closed when both are done
+func copyOutputs(outR io.Reader, stdout io.Writer, errR io.Reader, stderr io.Writer) <-chan struct{} {
+	var wg sync.WaitGroup
+
+	cp := func(w io.Writer, r io.Reader) {
+		defer wg.Done()
+		if w == nil {
+			w = ioutil.Discard
+		}
+		io.Copy(w, r)
+	}
+
+	wg.Add(2)
+	go cp(stdout, outR)
+	go cp(stderr, errR)
+
+	copied := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(copied)
+	}()
+
+	return copied
 }
 
 func mergeEnv(orig []string, new map[string]string) []string {
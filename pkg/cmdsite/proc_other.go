@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package cmdsite
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes the command the leader of a new process group, so that its children can be killed with it
+func setProcessGroup(command *exec.Cmd) {
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the command along with the children in its process group
+func killProcessGroup(command *exec.Cmd) {
+	if err := syscall.Kill(-command.Process.Pid, syscall.SIGKILL); err != nil {
+		command.Process.Kill()
+	}
+}
@@ -0,0 +1,14 @@
+package cmdsite
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, where the command is killed without its children
+func setProcessGroup(command *exec.Cmd) {
+}
+
+// killProcessGroup kills the command. Its children are left running, and their output is closed after DefaultWaitDelay
+func killProcessGroup(command *exec.Cmd) {
+	command.Process.Kill()
+}
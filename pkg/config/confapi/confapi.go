@@ -138,6 +138,7 @@ type Release struct {
 type VersionsFrom struct {
 	Exec            Exec
 	JSONPath        GetterJSONPath
+	HTTPJSONPath    HTTPJSONPath
 	GitTags         GitTags
 	GitHubTags      GitHubTags
 	GitHubReleases  GitHubReleases
@@ -159,6 +160,13 @@ type GetterJSONPath struct {
 	Description string
 }
 
+type HTTPJSONPath struct {
+	URL         func(map[string]interface{}) (string, error)
+	Versions    string
+	Headers     map[string]string
+	BearerToken string
+}
+
 type GitTags struct {
 	Source func(map[string]interface{}) (string, error)
 }
@@ -47,6 +47,13 @@ type JSONPath struct {
 	Description string `hcl:"description,attr"`
 }
 
+type HTTPJSONPath struct {
+	URL         string            `hcl:"url,attr"`
+	Versions    string            `hcl:"versions,attr"`
+	Headers     map[string]string `hcl:"headers,optional"`
+	BearerToken *string           `hcl:"bearer_token,attr"`
+}
+
 type GitTags struct {
 	Source string `hcl:"source,attr"`
 }
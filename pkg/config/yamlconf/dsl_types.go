@@ -26,6 +26,7 @@ type ReleaseSpec struct {
 type VersionsFrom struct {
 	Exec            Exec            `yaml:"exec"`
 	JSONPath        GetterJSONPath  `yaml:"jsonPath"`
+	HTTPJSONPath    HTTPJSONPath    `yaml:"httpJSONPath"`
 	GitTags         GitTags         `yaml:"gitTags"`
 	GitHubTags      GitHubTags      `yaml:"githubTags"`
 	GitHubReleases  GitHubReleases  `yaml:"githubReleases"`
@@ -37,6 +38,7 @@ type VersionsFrom struct {
 func (f VersionsFrom) IsDefined() bool {
 	return f.Exec.Command != "" ||
 		f.JSONPath.Source != "" ||
+		f.HTTPJSONPath.URL != "" ||
 		f.GitTags.Source != "" ||
 		f.GitHubReleases.Source != "" ||
 		f.DockerImageTags.Source != ""
@@ -55,6 +57,10 @@ func ToVersionsFrom(v VersionsFrom) confapi.VersionsFrom {
 	r.JSONPath.Source = NewRender("jsonPath.source", v.JSONPath.Source)
 	r.JSONPath.Description = v.JSONPath.Description
 	r.JSONPath.Versions = v.JSONPath.Versions
+	r.HTTPJSONPath.URL = NewRender("httpJSONPath.url", v.HTTPJSONPath.URL)
+	r.HTTPJSONPath.Versions = v.HTTPJSONPath.Versions
+	r.HTTPJSONPath.Headers = v.HTTPJSONPath.Headers
+	r.HTTPJSONPath.BearerToken = v.HTTPJSONPath.BearerToken
 	r.ValidVersionPattern = v.ValidVersionPattern
 	return r
 }
@@ -70,6 +76,13 @@ type GetterJSONPath struct {
 	Description string `yaml:"description"`
 }
 
+type HTTPJSONPath struct {
+	URL         string            `yaml:"url"`
+	Versions    string            `yaml:"versions"`
+	Headers     map[string]string `yaml:"headers"`
+	BearerToken string            `yaml:"bearerToken"`
+}
+
 type GitTags struct {
 	Source string `yaml:"source"`
 }
@@ -0,0 +1,48 @@
+package releasetracker
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAssetsNotSupported is returned by Assets for releases obtained from sources that have no concept of assets
+var ErrAssetsNotSupported = errors.New("listing assets is supported only for releases from githubReleases")
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name               string
+	BrowserDownloadURL string
+}
+
+// Assets returns the downloadable files attached to the release.
+//
+// Only releases obtained from `githubReleases` carry assets. ErrAssetsNotSupported is returned for other releases.
+func (p *Tracker) Assets(release *Release) ([]Asset, error) {
+	raw, ok := release.Meta["githubRelease"]
+	if !ok {
+		return nil, ErrAssetsNotSupported
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of github release: %T", raw)
+	}
+
+	items, _ := obj["assets"].([]interface{})
+
+	assets := []Asset{}
+
+	for _, item := range items {
+		a, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected type of github release asset: %T", item)
+		}
+
+		name, _ := a["name"].(string)
+		url, _ := a["browser_download_url"].(string)
+
+		assets = append(assets, Asset{Name: name, BrowserDownloadURL: url})
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,60 @@
+package releasetracker
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of trackers resolved at once by LatestForAll
+const DefaultBatchConcurrency = 8
+
+// LatestForAll resolves the latest release of every tracker with the constraint of the same key, at most
+// DefaultBatchConcurrency trackers at once. A tracker without the constraint resolves the latest of all the releases.
+//
+// Errors are collected per key, so that a failing tracker doesn't prevent the others from being resolved.
+// Each key is found in either of the returned maps.
+func LatestForAll(trackers map[string]*Tracker, constraints map[string]string) (map[string]*Release, map[string]error) {
+	keys := make([]string, 0, len(trackers))
+	for k := range trackers {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	releases := map[string]*Release{}
+	errs := map[string]error{}
+
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, DefaultBatchConcurrency)
+
+	var wg sync.WaitGroup
+
+	for _, k := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(k string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			r, err := trackers[k].Latest(constraints[k])
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[k] = err
+				return
+			}
+
+			releases[k] = r
+		}(k)
+	}
+
+	wg.Wait()
+
+	return releases, errs
+}
@@ -0,0 +1,38 @@
+package releasetracker
+
+import (
+	"github.com/variantdev/mod/pkg/cmdsite"
+	"testing"
+)
+
+func TestLatestForAll(t *testing.T) {
+	trackers := map[string]*Tracker{
+		"stable": newFakeExecTracker(t, Spec{}, "1.0.0\n1.1.0\n2.0.0\n"),
+		"legacy": newFakeExecTracker(t, Spec{}, "1.0.0\n1.1.0\n2.0.0\n"),
+	}
+
+	failing, err := New(Spec{VersionsFrom: VersionsFrom{Exec: Exec{Command: "list-versions"}}}, Commander(cmdsite.NewTester(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trackers["failing"] = failing
+
+	releases, errs := LatestForAll(trackers, map[string]string{"legacy": "< 2.0.0"})
+
+	if len(releases) != 2 {
+		t.Fatalf("unexpected number of releases: %v", releases)
+	}
+
+	if v := releases["stable"].Version; v != "2.0.0" {
+		t.Errorf("unexpected version of stable: expected=2.0.0, got=%s", v)
+	}
+
+	if v := releases["legacy"].Version; v != "1.1.0" {
+		t.Errorf("unexpected version of legacy: expected=1.1.0, got=%s", v)
+	}
+
+	if len(errs) != 1 || errs["failing"] == nil {
+		t.Errorf("expected the error of failing only, got %v", errs)
+	}
+}
@@ -0,0 +1,110 @@
+package releasetracker
+
+import (
+	"github.com/twpayne/go-vfs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheStats summarizes what is stored under the tracker's cache directory.
+type CacheStats struct {
+	// Entries is the number of top-level entries in the cache directory. Each entry corresponds to a cached source.
+	Entries int
+
+	// Size is the total size in bytes of all the files under the cache directory.
+	Size int64
+}
+
+// CacheStats returns the number of cache entries and their total size.
+// A missing cache directory is reported as an empty cache.
+func (p *Tracker) CacheStats() (*CacheStats, error) {
+	entries, err := p.cacheEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CacheStats{Entries: len(entries)}
+
+	for _, e := range entries {
+		err := vfs.Walk(p.fs, filepath.Join(p.cacheDir, e.Name()), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.Mode().IsRegular() {
+				stats.Size += info.Size()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
+
+// PruneCache removes every cache entry that has not been modified for `olderThan`.
+// An entry is as new as the newest file in it, as cached files are often rewritten in place.
+func (p *Tracker) PruneCache(olderThan time.Duration) error {
+	entries, err := p.cacheEntries()
+	if err != nil {
+		return err
+	}
+
+	threshold := time.Now().Add(-olderThan)
+
+	for _, e := range entries {
+		path := filepath.Join(p.cacheDir, e.Name())
+
+		modTime, err := p.newestModTime(path)
+		if err != nil {
+			return err
+		}
+
+		if !modTime.Before(threshold) {
+			continue
+		}
+
+		p.Logger.V(1).Info("pruning cache entry", "path", path, "modtime", modTime)
+
+		if err := p.fs.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newestModTime returns the newest modification time of the path and everything under it
+func (p *Tracker) newestModTime(path string) (time.Time, error) {
+	var newest time.Time
+
+	err := vfs.Walk(p.fs, path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return newest, err
+}
+
+func (p *Tracker) cacheEntries() ([]os.FileInfo, error) {
+	entries, err := p.fs.ReadDir(p.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entries, nil
+}
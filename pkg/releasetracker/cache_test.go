@@ -0,0 +1,129 @@
+package releasetracker
+
+import (
+	"github.com/twpayne/go-vfs/vfst"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTracker_PruneCache(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/.variant/mod/cache/stale/index.yaml":     "stale",
+		"/work/.variant/mod/cache/fresh/index.yaml":     "fresh!",
+		"/work/.variant/mod/cache/rewritten/index.yaml": "new",
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	// The directory of a cache entry keeps its modification time when the files in it are rewritten in place
+	old := time.Now().Add(-48 * time.Hour)
+	for _, path := range []string{
+		"/work/.variant/mod/cache/stale/index.yaml",
+		"/work/.variant/mod/cache/stale",
+		"/work/.variant/mod/cache/rewritten",
+	} {
+		if err := fs.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conf := Spec{VersionsFrom: VersionsFrom{GitTags: GitTags{Source: "github.com/mumoshu/variant"}}}
+
+	tracker, err := New(conf, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := tracker.CacheStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Entries != 3 || stats.Size != 14 {
+		t.Errorf("unexpected cache stats before pruning: %+v", stats)
+	}
+
+	if err := tracker.PruneCache(24 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	vfst.RunTests(t, fs, "",
+		vfst.TestPath("/work/.variant/mod/cache/stale", vfst.TestDoesNotExist),
+		vfst.TestPath("/work/.variant/mod/cache/fresh/index.yaml", vfst.TestContentsString("fresh!")),
+		vfst.TestPath("/work/.variant/mod/cache/rewritten/index.yaml", vfst.TestContentsString("new")),
+	)
+
+	stats, err = tracker.CacheStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Entries != 2 || stats.Size != 9 {
+		t.Errorf("unexpected cache stats after pruning: %+v", stats)
+	}
+}
+
+func TestTracker_CacheTTL(t *testing.T) {
+	fs, clean, err := vfst.NewTestFS(map[string]interface{}{"/work": &vfst.Dir{Perm: 0755}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	getter := &flakyGetter{responses: []*vhttpget.Response{
+		{StatusCode: http.StatusOK, Body: `[{"version": "1.0.0"}]`},
+		{StatusCode: http.StatusOK, Body: `[{"version": "1.1.0"}]`},
+	}}
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      "https://api.example.com/releases",
+		Versions: "$[*].version",
+	}}}
+
+	tracker, err := New(spec, FS(fs), WD("/work"), HttpGetter(getter), CacheTTL(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "1.0.0" {
+			t.Errorf("unexpected version on fetch %d: expected=1.0.0, got=%s", i, latest.Version)
+		}
+	}
+
+	if getter.calls != 1 {
+		t.Errorf("unexpected number of requests: expected=1, got=%d", getter.calls)
+	}
+
+	stats, err := tracker.CacheStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Entries != 1 {
+		t.Errorf("unexpected cache stats: %+v", stats)
+	}
+
+	if err := tracker.InvalidateCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.1.0" {
+		t.Errorf("unexpected version after invalidation: expected=1.1.0, got=%s", latest.Version)
+	}
+}
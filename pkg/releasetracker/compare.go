@@ -0,0 +1,69 @@
+package releasetracker
+
+import (
+	"github.com/Masterminds/semver"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func semverLess(a, b *semver.Version) bool {
+	return a.LessThan(b)
+}
+
+// BuildMetadataLess is the comparator for VersionComparator that orders versions by the semver precedence,
+// and then by the build metadata, so that `2021.10.1+2` is newer than `2021.10.1+1`.
+// The dot-separated identifiers of the build metadata are compared numerically when both are numeric,
+// and lexically otherwise. A version without the build metadata is older than the one with it.
+func BuildMetadataLess(a, b *semver.Version) bool {
+	if !a.Equal(b) {
+		return a.LessThan(b)
+	}
+
+	am, bm := a.Metadata(), b.Metadata()
+	if am == bm {
+		return false
+	}
+
+	if am == "" || bm == "" {
+		return am == ""
+	}
+
+	as, bs := strings.Split(am, "."), strings.Split(bm, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+
+		an, aerr := strconv.ParseUint(as[i], 10, 64)
+		bn, berr := strconv.ParseUint(bs[i], 10, 64)
+		if aerr == nil && berr == nil {
+			return an < bn
+		}
+
+		return as[i] < bs[i]
+	}
+
+	return len(as) < len(bs)
+}
+
+// less reports whether the version a is older than b, by the comparator set via VersionComparator
+func (p *Tracker) less(a, b *semver.Version) bool {
+	if p.versionLess != nil {
+		return p.versionLess(a, b)
+	}
+
+	return semverLess(a, b)
+}
+
+// sortReleases sorts the releases in ascending order of versions, unless Spec.PreserveOrder is set
+func (p *Tracker) sortReleases(rs []*Release) {
+	if p.Spec.PreserveOrder {
+		return
+	}
+
+	sort.SliceStable(rs, func(i, j int) bool {
+		return p.less(rs[i].Semver, rs[j].Semver)
+	})
+}
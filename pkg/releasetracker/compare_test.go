@@ -0,0 +1,72 @@
+package releasetracker
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestBuildMetadataLess(t *testing.T) {
+	testcases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "1.0.0+1", b: "1.0.0+2", expected: true},
+		{a: "1.0.0+2", b: "1.0.0+10", expected: true},
+		{a: "1.0.0+10", b: "1.0.0+2", expected: false},
+		{a: "1.0.0", b: "1.0.0+1", expected: true},
+		{a: "1.0.0+1", b: "1.0.0", expected: false},
+		{a: "1.0.0+1.2", b: "1.0.0+1.10", expected: true},
+		{a: "1.0.0+1", b: "1.0.0+1.1", expected: true},
+		{a: "1.0.0+abc", b: "1.0.0+abd", expected: true},
+		{a: "1.0.0+9", b: "1.0.1+1", expected: true},
+		{a: "1.0.0+1", b: "1.0.0+1", expected: false},
+	}
+
+	for _, tc := range testcases {
+		a, b := semver.MustParse(tc.a), semver.MustParse(tc.b)
+
+		if got := BuildMetadataLess(a, b); got != tc.expected {
+			t.Errorf("%s < %s: expected=%v, got=%v", tc.a, tc.b, tc.expected, got)
+		}
+	}
+}
+
+func TestTracker_VersionComparator(t *testing.T) {
+	versions := "2021.10.1+2\n2021.10.1+10\n2021.9.30+5\n2021.10.1+1\n"
+
+	tracker := newFakeExecTracker(t, Spec{}, versions, VersionComparator(BuildMetadataLess))
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"2021.9.30+5", "2021.10.1+1", "2021.10.1+2", "2021.10.1+10"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "2021.10.1+10" {
+		t.Errorf("unexpected version: expected=2021.10.1+10, got=%s", latest.Version)
+	}
+
+	// The build metadata is ignored by default, in which case the first one of the same precedence wins
+	latest, err = newFakeExecTracker(t, Spec{}, versions).Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "2021.10.1+2" {
+		t.Errorf("unexpected default version: expected=2021.10.1+2, got=%s", latest.Version)
+	}
+}
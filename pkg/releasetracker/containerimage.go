@@ -0,0 +1,41 @@
+package releasetracker
+
+import (
+	"fmt"
+)
+
+type containerImageTagsProvider struct {
+	spec ContainerImageTags
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &containerImageTagsProvider{}
+
+// newContainerImageTagsProvider returns the provider for the registry, or the one for Docker Hub when the registry is empty
+func newContainerImageTagsProvider(spec ContainerImageTags, r *Tracker) ReleaseProvider {
+	if spec.Registry == "" {
+		return newDockerHubImageTagsProvider(DockerImageTags{Source: spec.Repository}, r)
+	}
+
+	return &containerImageTagsProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *containerImageTagsProvider) All() ([]*Release, error) {
+	registryURL, repo := registryRepository(p.spec.Registry, p.spec.Repository)
+
+	password, err := p.runtime.resolveSecret(p.spec.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := p.runtime.registryTags(registryURL, repo, p.spec.Username, password)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags of %s in %s: %w", repo, registryURL, err)
+	}
+
+	return p.runtime.versionsToReleases(tags)
+}
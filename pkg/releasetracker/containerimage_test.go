@@ -0,0 +1,84 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProvider_ContainerImageTags(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if _, _, ok := r.BasicAuth(); ok || r.URL.Query().Get("scope") != "repository:myorg/myapp:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token": "anonymous-token"}`)
+		case "/v2/myorg/myapp/tags/list":
+			if r.Header.Get("Authorization") != "Bearer anonymous-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="ghcr.io",scope="repository:myorg/myapp:pull"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"name": "myorg/myapp", "tags": ["v1.0.0", "v1.1.0", "latest", "sha-2f1e3c4"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	spec := Spec{VersionsFrom: VersionsFrom{ContainerImageTags: ContainerImageTags{
+		Registry:   srv.URL,
+		Repository: "myorg/myapp",
+	}}}
+
+	tracker, err := New(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0"}, got); d != "" {
+		t.Errorf("%s", d)
+	}
+}
+
+func TestProvider_ContainerImageTags_DockerHub(t *testing.T) {
+	spec := Spec{VersionsFrom: VersionsFrom{ContainerImageTags: ContainerImageTags{
+		Repository: "mumoshu/helmfile-chatops",
+	}}}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/mumoshu/helmfile-chatops/tags/?page_size=1000"}: `{"next": null, "results": [{"name": "0.2.0"}, {"name": "0.1.0"}]}`,
+	}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.2.0" {
+		t.Errorf("unexpected version: expected=0.2.0, got=%v", latest.Version)
+	}
+}
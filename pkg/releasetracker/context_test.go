@@ -0,0 +1,184 @@
+package releasetracker
+
+import (
+	"context"
+	"errors"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// slowGetter responds immediately to the first page, and never responds in time to the others
+type slowGetter struct {
+	first string
+	pages map[string]string
+}
+
+func (g *slowGetter) DoRequest(url string, opt ...vhttpget.Option) (string, error) {
+	if url != g.first {
+		time.Sleep(time.Second)
+	}
+	return g.pages[url], nil
+}
+
+func TestTracker_GetReleasesContext_Deadline(t *testing.T) {
+	getter := &slowGetter{
+		first: "https://api.example.com/releases",
+		pages: map[string]string{
+			"https://api.example.com/releases":            `{"items": [{"version": "1.0.0"}], "next": "abc"}`,
+			"https://api.example.com/releases?cursor=abc": `{"items": [{"version": "1.1.0"}], "next": ""}`,
+		},
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      "https://api.example.com/releases",
+		Versions: "$.items[*].version",
+		Cursor:   CursorPagination{Path: "$.next", Param: "cursor"},
+	}}}
+
+	for _, partial := range []bool{false, true} {
+		spec.PartialResultsOnDeadline = partial
+
+		tracker, err := New(spec, HttpGetter(getter))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+		start := time.Now()
+
+		rs, err := tracker.GetReleasesContext(ctx)
+
+		cancel()
+
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("partial=%v: fetching did not stop on the deadline: took %v", partial, elapsed)
+		}
+
+		if partial {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(rs) != 1 || rs[0].Version != "1.0.0" {
+				t.Errorf("unexpected partial releases: %v", rs)
+			}
+		} else if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline error, got %v", err)
+		}
+	}
+}
+
+func TestTracker_LatestContext_ProviderWithoutContext(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	tracker := newFakeExecTracker(t, Spec{}, "1.0.0\n", ProviderMiddlewares(func(next ReleaseProvider) ReleaseProvider {
+		return ProviderFunc(func() ([]*Release, error) {
+			<-block
+			return next.All()
+		})
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := tracker.LatestContext(ctx, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+}
+
+func TestTracker_GetReleasesContext_AbortsRequest(t *testing.T) {
+	aborted := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer srv.Close()
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      srv.URL + "/releases",
+		Versions: "$[*].version",
+	}}}
+
+	tracker, err := New(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := tracker.GetReleasesContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Error("the in-flight request was not aborted")
+	}
+}
+
+func TestTracker_GetReleasesContext_KillsCommand(t *testing.T) {
+	spec := Spec{VersionsFrom: VersionsFrom{Exec: Exec{Command: "sh", Args: []string{"-c", "sleep 5; echo 1.0.0"}}}}
+
+	tracker, err := New(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := tracker.GetReleasesContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("the command was not killed on the deadline: took %v", elapsed)
+	}
+}
+
+func TestTracker_HTTPTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      srv.URL + "/releases",
+		Versions: "$[*].version",
+	}}}
+
+	tracker, err := New(spec, HTTPTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	if _, err := tracker.GetReleases(); err == nil {
+		t.Error("expected timeout error, got none")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the request did not time out: took %v", elapsed)
+	}
+
+	if _, err := New(spec, HTTPTimeout(0)); err == nil {
+		t.Error("expected error for zero timeout, got none")
+	}
+}
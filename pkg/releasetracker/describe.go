@@ -0,0 +1,120 @@
+package releasetracker
+
+import "net/http"
+
+// ProviderInfo describes how the releases are fetched from the versions source, for auditing and validating configs
+type ProviderInfo struct {
+	// Kind is the kind of the versions source, like `githubReleases`
+	Kind string
+
+	// Source is the URL the releases are fetched from, or the command line for exec. Empty when unknown
+	Source string
+
+	// Query is the JSONPath expression or the jq query to extract versions, like `$[*].tag_name`. Empty when unused
+	Query string
+
+	// Authenticated reports whether the requests carry credentials, like a token or an `Authorization` header.
+	// Nil when it can't be told from the spec, like for exec or s3 whose credentials come from the environment
+	Authenticated *bool
+
+	// Filter, VersionCapture, TrimVersionPrefix and TrimVersionSuffix are the ones of the spec applied to each version
+	Filter            string
+	VersionCapture    string
+	TrimVersionPrefix string
+	TrimVersionSuffix string
+
+	ExcludePrereleases bool
+
+	ExcludeVersions []string
+	IncludeVersions []string
+
+	// Fallbacks are the kinds of the fallbacks in the order they are tried
+	Fallbacks []string
+}
+
+// Describe returns how the releases are fetched from the versions source, following the same decision as GetProvider.
+// Nothing is fetched, so it can be used to check a config without network access.
+func (p *Tracker) Describe() (ProviderInfo, error) {
+	kind, pp, err := p.resolveBaseProvider(p.Spec.VersionsFrom)
+	if err != nil {
+		return ProviderInfo{}, err
+	}
+
+	info := ProviderInfo{
+		Kind:               kind,
+		Source:             p.fetchSource(p.Spec.VersionsFrom),
+		Filter:             p.Spec.Filter,
+		VersionCapture:     p.Spec.VersionCapture,
+		TrimVersionPrefix:  p.Spec.TrimVersionPrefix,
+		TrimVersionSuffix:  p.Spec.TrimVersionSuffix,
+		ExcludePrereleases: p.Spec.ExcludePrereleases,
+		ExcludeVersions:    p.Spec.ExcludeVersions,
+		IncludeVersions:    p.Spec.IncludeVersions,
+	}
+
+	switch typed := pp.(type) {
+	case *httpJsonPathProvider:
+		info.Query = firstNonEmpty(typed.jq, typed.jsonpath)
+		info.Authenticated = authenticated(typed.token != "" || hasCredentialHeader(typed.headers))
+	case *dockerImageTagsProvider:
+		info.Query = typed.hub.jsonpath
+		username, password := typed.credentials()
+		info.Authenticated = authenticated(username != "" || password != "")
+	case *containerImageTagsProvider:
+		info.Authenticated = authenticated(typed.spec.Username != "" || typed.spec.Password != "")
+	case *helmOCIProvider:
+		info.Authenticated = authenticated(typed.spec.Username != "" || typed.spec.Password != "")
+	case *getterJsonPathProvider:
+		info.Query = firstNonEmpty(typed.spec.JQ, typed.spec.Versions)
+		info.Authenticated = authenticated(hasCredentialHeader(typed.spec.Headers))
+	case *fileProvider:
+		info.Query = firstNonEmpty(typed.spec.JQ, typed.spec.Versions)
+		info.Authenticated = authenticated(false)
+	case *gitFileProvider:
+		info.Query = firstNonEmpty(typed.spec.Versions, typed.spec.Pattern)
+		info.Authenticated = authenticated(typed.spec.Token != "")
+	case *kvProvider:
+		switch kv := typed.kv.(type) {
+		case *consulKV:
+			info.Authenticated = authenticated(kv.spec.Token != "")
+		case *etcdKV:
+			info.Authenticated = authenticated(kv.spec.Username != "" || kv.spec.Password != "")
+		}
+	case *goProxyProvider, *pypiProvider, *mavenProvider:
+		info.Authenticated = authenticated(false)
+	}
+
+	for _, f := range p.Spec.VersionsFrom.Fallbacks {
+		if sources := f.configuredSources(); len(sources) > 0 {
+			info.Fallbacks = append(info.Fallbacks, sources[0])
+		}
+	}
+
+	return info, nil
+}
+
+func authenticated(b bool) *bool {
+	return &b
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
+
+// hasCredentialHeader reports whether the headers include one carrying credentials, like `Authorization`
+func hasCredentialHeader(headers map[string]string) bool {
+	for k := range headers {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Private-Token", "X-Api-Key":
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestTracker_Describe(t *testing.T) {
+	testcases := []struct {
+		spec     Spec
+		expected ProviderInfo
+	}{
+		{
+			spec: Spec{
+				VersionsFrom: VersionsFrom{
+					GitHubReleases: GitHubReleases{Source: "mumoshu/variant", Token: "env:GITHUB_TOKEN"},
+					Fallbacks:      []VersionsFrom{{GitTags: GitTags{Source: "github.com/mumoshu/variant"}}},
+				},
+				Filter:             "^v",
+				ExcludePrereleases: true,
+			},
+			expected: ProviderInfo{
+				Kind:               "githubReleases",
+				Source:             "https://api.github.com/repos/mumoshu/variant/releases",
+				Query:              "$[*].tag_name",
+				Authenticated:      authenticated(true),
+				Filter:             "^v",
+				ExcludePrereleases: true,
+				Fallbacks:          []string{"gitTags"},
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+				URL:     "https://releases.example.com/myapp.json",
+				JQ:      "[.items[].version]",
+				Headers: map[string]string{"x-api-key": "$API_KEY"},
+			}}},
+			expected: ProviderInfo{
+				Kind:          "httpJSONPath",
+				Source:        "https://releases.example.com/myapp.json",
+				Query:         "[.items[].version]",
+				Authenticated: authenticated(true),
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{Exec: Exec{Command: "list-versions", Args: []string{"--all"}}}, VersionCapture: `app-(.+)`},
+			expected: ProviderInfo{
+				Kind:           "exec",
+				Source:         "list-versions --all",
+				VersionCapture: `app-(.+)`,
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{DockerImageTags: DockerImageTags{Source: "myorg/myapp"}}},
+			expected: ProviderInfo{
+				Kind:          "dockerImageTags",
+				Source:        "https://registry.hub.docker.com/v2/repositories/myorg/myapp/tags/",
+				Query:         "$.results[*].name",
+				Authenticated: authenticated(true),
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{HelmOCI: HelmOCI{Registry: "oci://registry.example.com/charts", Chart: "mychart", Username: "me"}}},
+			expected: ProviderInfo{
+				Kind:          "helmOCI",
+				Authenticated: authenticated(true),
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{ConsulKV: ConsulKV{Key: "releases/myapp"}}},
+			expected: ProviderInfo{
+				Kind:          "consulKV",
+				Authenticated: authenticated(false),
+			},
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{S3: S3Bucket{Bucket: "releases"}}},
+			expected: ProviderInfo{
+				Kind:   "s3",
+				Source: "s3://releases/",
+			},
+		},
+	}
+
+	defer setenv(t, "DOCKER_USERNAME", "me")()
+
+	for _, tc := range testcases {
+		tracker, err := New(tc.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := tracker.Describe()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if d := cmp.Diff(tc.expected, info); d != "" {
+			t.Errorf("%s: unexpected info: %s", tc.expected.Kind, d)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"strconv"
+)
+
+const (
+	DuplicatePolicyFirst           = "first"
+	DuplicatePolicyLast            = "last"
+	DuplicatePolicyHighestMetadata = "highestMetadata"
+	DuplicatePolicyError           = "error"
+	DuplicatePolicyKeepAll         = "keepAll"
+)
+
+const (
+	DuplicateKeySemver             = "semver"
+	DuplicateKeySemverWithMetadata = "semverWithMetadata"
+)
+
+// applyDuplicatePolicy canonicalizes the releases by collapsing variants of the same release, like `v1.2.0`, `1.2.0`,
+// and `1.2.0+build`, into one representative picked according to the duplicate policy.
+// The "v" prefix is always ignored, whereas build metadata is ignored unless the duplicate key is `semverWithMetadata`.
+//
+// Without a duplicate policy, only the releases of the identical semver including build metadata are collapsed,
+// like `1.2.0` and `v1.2.0` returned for two refs of the same tag.
+//
+// Duplicates needn't be adjacent to each other, as the releases can come from pages or sources that aren't sorted
+// together. The representatives are in the order of the first appearances of their versions.
+func (p *Tracker) applyDuplicatePolicy(rs []*Release) ([]*Release, error) {
+	policy := p.Spec.DuplicatePolicy
+
+	switch policy {
+	case DuplicatePolicyKeepAll:
+		return rs, nil
+	case "", DuplicatePolicyFirst, DuplicatePolicyLast, DuplicatePolicyHighestMetadata, DuplicatePolicyError:
+	default:
+		return nil, fmt.Errorf("unsupported duplicatePolicy %q: it must be one of %q, %q, %q, %q, or %q", policy, DuplicatePolicyFirst, DuplicatePolicyLast, DuplicatePolicyHighestMetadata, DuplicatePolicyError, DuplicatePolicyKeepAll)
+	}
+
+	withMetadata := false
+
+	switch p.Spec.DuplicateKey {
+	case "", DuplicateKeySemver:
+	case DuplicateKeySemverWithMetadata:
+		withMetadata = true
+	default:
+		return nil, fmt.Errorf("unsupported duplicateKey %q: it must be either %q or %q", p.Spec.DuplicateKey, DuplicateKeySemver, DuplicateKeySemverWithMetadata)
+	}
+
+	if policy == "" {
+		withMetadata = true
+	}
+
+	var result []*Release
+
+	for _, same := range groupBySemver(rs) {
+		groups := [][]*Release{same}
+		if withMetadata {
+			groups = groupByMetadata(same)
+		}
+
+		for _, dups := range groups {
+			if len(dups) > 1 && policy == DuplicatePolicyError {
+				var vs []string
+				for _, d := range dups {
+					vs = append(vs, d.Version)
+				}
+				return nil, fmt.Errorf("duplicate releases found for %s: %v", dupKey(dups[0].Semver, withMetadata), vs)
+			}
+
+			result = append(result, pickDuplicate(policy, dups))
+		}
+	}
+
+	return result, nil
+}
+
+// groupBySemver splits releases by semver ignoring build metadata, in the order of their first appearances
+func groupBySemver(rs []*Release) [][]*Release {
+	var groups [][]*Release
+
+	index := map[string]int{}
+
+	for _, r := range rs {
+		k := withoutMetadata(r.Semver)
+
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], r)
+	}
+
+	return groups
+}
+
+// groupByMetadata splits releases of the same semver by build metadata, in the order of their first appearances
+func groupByMetadata(rs []*Release) [][]*Release {
+	var groups [][]*Release
+
+	index := map[string]int{}
+
+	for _, r := range rs {
+		m := r.Semver.Metadata()
+
+		i, ok := index[m]
+		if !ok {
+			i = len(groups)
+			index[m] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], r)
+	}
+
+	return groups
+}
+
+func dupKey(v *semver.Version, withMetadata bool) string {
+	if withMetadata {
+		return v.String()
+	}
+	return withoutMetadata(v)
+}
+
+func pickDuplicate(policy string, dups []*Release) *Release {
+	switch policy {
+	case "":
+		// Prefer the one from e.g. a GitHub release over the bare tag of the same version
+		for _, d := range dups {
+			if d.Description != "" {
+				return d
+			}
+		}
+	case DuplicatePolicyLast:
+		return dups[len(dups)-1]
+	case DuplicatePolicyHighestMetadata:
+		picked := dups[0]
+		for _, d := range dups[1:] {
+			if metadataLess(picked.Semver.Metadata(), d.Semver.Metadata()) {
+				picked = d
+			}
+		}
+		return picked
+	}
+
+	return dups[0]
+}
+
+// metadataLess compares build metadata numerically when both are numbers, and lexically otherwise
+func metadataLess(a, b string) bool {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+
+	return a < b
+}
+
+// withoutMetadata returns the version string with the build metadata removed, like `1.2.0-rc.1` for `1.2.0-rc.1+b`
+func withoutMetadata(v *semver.Version) string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	if v.Prerelease() != "" {
+		s += "-" + v.Prerelease()
+	}
+	return s
+}
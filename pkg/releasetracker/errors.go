@@ -0,0 +1,64 @@
+package releasetracker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNoReleases is matched by the error of Latest and the like when the source returned no releases at all
+	ErrNoReleases = errors.New("no releases found")
+
+	// ErrNoMatchingRelease is matched by the error of Latest and the like when the source returned releases
+	// but none of them matches the constraint
+	ErrNoMatchingRelease = errors.New("no release matching the constraint found")
+
+	// ErrAlreadyLatest is matched by the error of Next when no release matching the constraint is newer than the current one
+	ErrAlreadyLatest = errors.New("already the latest release")
+
+	// ErrRateLimited is matched by the error of Latest and the like when the API refused the requests due to the rate limit
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// NoMatchingReleaseError is returned when no release matches the constraint.
+// Use errors.Is with ErrNoReleases and ErrNoMatchingRelease to tell an empty source from a too strict constraint.
+type NoMatchingReleaseError struct {
+	Constraint string
+
+	// Versions are the versions of all the releases the constraint was checked against
+	Versions []string
+}
+
+func (e *NoMatchingReleaseError) Error() string {
+	return fmt.Sprintf("no semver matching %q found in %v", e.Constraint, e.Versions)
+}
+
+func (e *NoMatchingReleaseError) Is(target error) bool {
+	if len(e.Versions) == 0 {
+		return target == ErrNoReleases
+	}
+
+	return target == ErrNoMatchingRelease
+}
+
+// RateLimitError is returned when the API, like GitHub's, refused the request due to the rate limit.
+// Use errors.As to obtain the time to retry after.
+type RateLimitError struct {
+	URL string
+
+	// Reset is when the rate limit resets. Zero when the API didn't tell
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return fmt.Sprintf("getting %s: rate limited", e.URL)
+	}
+
+	return fmt.Sprintf("getting %s: rate limited until %s", e.URL, e.Reset.Format(time.RFC3339))
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
@@ -0,0 +1,107 @@
+package releasetracker
+
+import (
+	"errors"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTracker_Latest_Errors(t *testing.T) {
+	empty := newFakeExecTracker(t, Spec{}, "")
+
+	_, err := empty.Latest("")
+	if !errors.Is(err, ErrNoReleases) || errors.Is(err, ErrNoMatchingRelease) {
+		t.Errorf("expected ErrNoReleases only, got %v", err)
+	}
+
+	tracker := newFakeExecTracker(t, Spec{}, "1.0.0\n1.1.0\n")
+
+	_, err = tracker.Latest(">= 2.0.0")
+	if !errors.Is(err, ErrNoMatchingRelease) || errors.Is(err, ErrNoReleases) {
+		t.Errorf("expected ErrNoMatchingRelease only, got %v", err)
+	}
+
+	var noMatch *NoMatchingReleaseError
+	if !errors.As(err, &noMatch) {
+		t.Fatalf("expected NoMatchingReleaseError, got %T", err)
+	}
+
+	if noMatch.Constraint != ">= 2.0.0" || len(noMatch.Versions) != 2 {
+		t.Errorf("unexpected error: %+v", noMatch)
+	}
+
+	expected := `no semver matching ">= 2.0.0" found in [1.0.0 1.1.0]`
+	if err.Error() != expected {
+		t.Errorf("unexpected message: expected=%s, got=%s", expected, err.Error())
+	}
+}
+
+func TestTracker_Latest_EmptyObjects(t *testing.T) {
+	spec := Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}}
+
+	empty := &vhttpget.Response{StatusCode: http.StatusOK, Body: `[]`}
+	prereleases := &vhttpget.Response{StatusCode: http.StatusOK, Body: `[{"tag_name": "v0.37.0-rc.1", "prerelease": true}]`}
+	released := &vhttpget.Response{StatusCode: http.StatusOK, Body: `[{"tag_name": "v0.37.0"}]`}
+
+	for _, responses := range [][]*vhttpget.Response{{empty}, {prereleases}} {
+		tracker, err := New(spec, HttpGetter(&flakyGetter{responses: responses}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tracker.Latest(""); !errors.Is(err, ErrNoReleases) {
+			t.Errorf("%s: expected ErrNoReleases, got %v", responses[0].Body, err)
+		}
+	}
+
+	retrying := spec
+	retrying.RetryOnEmpty = true
+	retrying.RetryOnEmptyBackoff = time.Millisecond
+
+	getter := &flakyGetter{responses: []*vhttpget.Response{empty, released}}
+
+	tracker, err := New(retrying, HttpGetter(getter))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.37.0" || getter.calls != 2 {
+		t.Errorf("unexpected latest after retrying on empty: version=%s, calls=%d", latest.Version, getter.calls)
+	}
+}
+
+func TestTracker_Latest_RateLimited(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]vhttpget.Response{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: {
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"1600000000"}},
+			Body:       `{"message": "API rate limit exceeded"}`,
+		},
+	}
+
+	tracker, err := New(Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}}, HttpGetter(vhttpget.NewResponseTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tracker.Latest("")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	var rateLimited *RateLimitError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("expected RateLimitError, got %T", err)
+	}
+
+	if want := time.Unix(1600000000, 0); !rateLimited.Reset.Equal(want) {
+		t.Errorf("unexpected reset: expected=%v, got=%v", want, rateLimited.Reset)
+	}
+}
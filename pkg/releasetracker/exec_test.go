@@ -0,0 +1,95 @@
+package releasetracker
+
+import (
+	"github.com/variantdev/mod/pkg/cmdsite"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setenv sets the envvar and returns the func to restore the previous value, in place of t.Setenv which needs Go 1.17
+func setenv(t *testing.T, key, value string) func() {
+	prev, ok := os.LookupEnv(key)
+
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestProvider_Exec_Env(t *testing.T) {
+	defer setenv(t, "API_TOKEN", "secret")()
+
+	cmdr := cmdsite.NewTester(map[cmdsite.CommandInput]cmdsite.CommandOutput{
+		cmdsite.NewInput("sh", []string{"-c", "list-versions"}, map[string]string{"TOKEN": "secret"}): {Stdout: "1.0.0\r\n\r\n1.1.0\r\n"},
+	})
+
+	spec := Spec{VersionsFrom: VersionsFrom{Exec: Exec{
+		Command: "sh",
+		Args:    []string{"-c", "list-versions"},
+		Env:     map[string]string{"TOKEN": "$API_TOKEN"},
+	}}}
+
+	tracker, err := New(spec, Commander(cmdr))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.1.0" {
+		t.Errorf("unexpected version: expected=1.1.0, got=%s", latest.Version)
+	}
+}
+
+func TestProvider_Exec_Dir(t *testing.T) {
+	wd, err := ioutil.TempDir("", "variant-mod-exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(wd)
+
+	if err := os.Mkdir(filepath.Join(wd, "versions"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(wd, "versions", "VERSIONS"), []byte("1.0.0\n1.2.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{Exec: Exec{Command: "cat", Args: []string{"VERSIONS"}, Dir: "versions"}}}
+
+	tracker, err := New(spec, WD(wd))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.2.0" {
+		t.Errorf("unexpected version: expected=1.2.0, got=%s", latest.Version)
+	}
+
+	custom, err := New(spec, WD(wd), Commander(cmdsite.NewTester(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := custom.Latest(""); err == nil {
+		t.Error("expected error for dir with a custom command runner, got none")
+	}
+}
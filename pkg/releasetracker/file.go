@@ -0,0 +1,40 @@
+package releasetracker
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type fileProvider struct {
+	spec LocalFile
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &fileProvider{}
+
+func newFileProvider(spec LocalFile, r *Tracker) *fileProvider {
+	return &fileProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *fileProvider) All() ([]*Release, error) {
+	path := p.spec.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.runtime.AbsWorkDir, path)
+	}
+
+	bs, err := p.runtime.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	vs, err := p.runtime.queryDocuments(bs, versionsQuery{jsonpath: p.spec.Versions, jq: p.spec.JQ})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
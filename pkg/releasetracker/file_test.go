@@ -0,0 +1,79 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/twpayne/go-vfs/vfst"
+	"strings"
+	"testing"
+)
+
+func TestProvider_File(t *testing.T) {
+	testfs, clean, err := vfst.NewTestFS(map[string]interface{}{
+		"/work/versions.json": `{"releases": [{"version": "v1.2.0"}, {"version": "v1.10.0"}, {"version": "v1.3.0"}]}`,
+		"/etc/versions.yaml": `versions:
+- 2.0.0
+- 2.1.0
+`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	testcases := []struct {
+		spec     LocalFile
+		expected []string
+	}{
+		{
+			spec:     LocalFile{Path: "versions.json", Versions: "$.releases[*].version"},
+			expected: []string{"1.2.0", "1.3.0", "1.10.0"},
+		},
+		{
+			spec:     LocalFile{Path: "/etc/versions.yaml", Versions: "$.versions[*]"},
+			expected: []string{"2.0.0", "2.1.0"},
+		},
+		{
+			spec:     LocalFile{Path: "versions.json", JQ: `[.releases[].version | select(startswith("v1.1"))]`},
+			expected: []string{"1.10.0"},
+		},
+	}
+
+	for _, tc := range testcases {
+		tracker, err := New(Spec{VersionsFrom: VersionsFrom{File: tc.spec}}, FS(testfs), WD("/work"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.spec.Path, err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("%s: unexpected versions: %s", tc.spec.Path, d)
+		}
+	}
+}
+
+func TestProvider_File_NotFound(t *testing.T) {
+	testfs, clean, err := vfst.NewTestFS(map[string]interface{}{"/work/.keep": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	tracker, err := New(Spec{VersionsFrom: VersionsFrom{File: LocalFile{Path: "versions.json", Versions: "$"}}}, FS(testfs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tracker.GetReleases()
+	if err == nil || !strings.Contains(err.Error(), "reading /work/versions.json") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
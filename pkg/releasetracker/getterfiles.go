@@ -0,0 +1,102 @@
+package releasetracker
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultMaxConcurrency is the number of files read at once when MaxConcurrency is not set
+const DefaultMaxConcurrency = 4
+
+// releasesFromGetterFiles extracts versions from every file in the source directory whose name matches `files`.
+// Files are read and queried concurrently, and the versions are merged in the order of the file names
+// so that the result doesn't depend on the scheduling.
+func (p *Tracker) releasesFromGetterFiles(spec GetterJSONPath) ([]*Release, error) {
+	dir, err := p.dep.ResolveDir(spec.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := p.fs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		ok, err := filepath.Match(spec.Files, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("files: %w", err)
+		}
+
+		if ok {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files matching %q found in %s", spec.Files, spec.Source)
+	}
+
+	workers := p.Spec.MaxConcurrency
+	if workers <= 0 {
+		workers = DefaultMaxConcurrency
+	}
+
+	type result struct {
+		versions []string
+		err      error
+	}
+
+	results := make([]result, len(paths))
+
+	sem := make(chan struct{}, workers)
+
+	var wg sync.WaitGroup
+
+	for i := range paths {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			bs, err := p.fs.ReadFile(paths[i])
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			vs, err := p.queryDocuments(bs, versionsQuery{jsonpath: spec.Versions, jq: spec.JQ})
+			if err != nil {
+				results[i].err = fmt.Errorf("%s: %w", filepath.Base(paths[i]), err)
+				return
+			}
+
+			results[i].versions = vs
+		}(i)
+	}
+
+	wg.Wait()
+
+	var vs []string
+
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		vs = append(vs, r.versions...)
+	}
+
+	return p.versionsToReleases(vs)
+}
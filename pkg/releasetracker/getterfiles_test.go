@@ -0,0 +1,84 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twpayne/go-vfs"
+	"github.com/twpayne/go-vfs/vfst"
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrencyFS records the maximum number of concurrent ReadFile calls
+type concurrencyFS struct {
+	vfs.FS
+
+	mu       sync.Mutex
+	inflight int
+	max      int
+}
+
+func (fs *concurrencyFS) ReadFile(name string) ([]byte, error) {
+	fs.mu.Lock()
+	fs.inflight++
+	if fs.inflight > fs.max {
+		fs.max = fs.inflight
+	}
+	fs.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	fs.mu.Lock()
+	fs.inflight--
+	fs.mu.Unlock()
+
+	return fs.FS.ReadFile(name)
+}
+
+func TestProvider_JSONPath_Files(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/manifests/README.md": "not a manifest",
+	}
+	for i := 0; i < 6; i++ {
+		files[fmt.Sprintf("/work/manifests/app-%d.yaml", i)] = fmt.Sprintf("version: 1.%d.0\n", i)
+	}
+
+	testfs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	fs := &concurrencyFS{FS: testfs}
+
+	spec := Spec{
+		VersionsFrom: VersionsFrom{
+			JSONPath: GetterJSONPath{Source: "/work/manifests", Files: "*.yaml", Versions: "$.version"},
+		},
+		MaxConcurrency: 2,
+	}
+
+	tracker, err := New(spec, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0", "1.2.0", "1.3.0", "1.4.0", "1.5.0"}, vs); d != "" {
+		t.Errorf("%s", d)
+	}
+
+	if fs.max > 2 {
+		t.Errorf("too many files read at once: limit=2, got=%d", fs.max)
+	}
+}
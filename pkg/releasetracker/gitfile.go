@@ -0,0 +1,199 @@
+package releasetracker
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type gitFileProvider struct {
+	spec GitFile
+
+	runtime *Tracker
+}
+
+var _ ContextReleaseProvider = &gitFileProvider{}
+
+func newGitFileProvider(spec GitFile, r *Tracker) *gitFileProvider {
+	return &gitFileProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *gitFileProvider) All() ([]*Release, error) {
+	return p.AllContext(context.Background())
+}
+
+func (p *gitFileProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	content, err := p.runtime.readGitFile(ctx, p.spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs []string
+
+	if p.spec.Versions != "" {
+		vs, err = p.runtime.versionsFromDocuments([]byte(content), p.spec.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.spec.Path, err)
+		}
+	} else if p.spec.Pattern != "" {
+		vs, err = versionsFromPattern(content, p.spec.Pattern)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("gitFile: either versions or pattern must be specified")
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
+
+// readGitFile reads the file at the ref of the repository by shallow-fetching only the ref into a temporary repository.
+// It runs the git CLI rather than a git library, so that the user's SSH agent and credential helpers work,
+// and the commands are killed once the context is done.
+func (p *Tracker) readGitFile(ctx context.Context, spec GitFile) (string, error) {
+	if spec.Path == "" {
+		return "", fmt.Errorf("gitFile: path must be specified")
+	}
+
+	token, err := p.resolveSecret(spec.Token)
+	if err != nil {
+		return "", err
+	}
+
+	env, err := gitAuthEnv(spec.Repo, token)
+	if err != nil {
+		return "", err
+	}
+
+	if len(env) > 0 {
+		out, err := p.git(ctx, nil, "", "version")
+		if err != nil {
+			return "", err
+		}
+
+		if err := checkGitVersionForEnvConfig(out); err != nil {
+			return "", err
+		}
+	}
+
+	ref := spec.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	dir, err := ioutil.TempDir("", "variant-mod-gitfile")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := p.git(ctx, nil, dir, "init", "-q"); err != nil {
+		return "", err
+	}
+
+	if _, err := p.git(ctx, env, dir, "fetch", "-q", "--depth", "1", spec.Repo, ref); err != nil {
+		return "", fmt.Errorf("fetching %s from %s: %w", ref, spec.Repo, err)
+	}
+
+	content, err := p.git(ctx, nil, dir, "show", "FETCH_HEAD:"+filepath.ToSlash(spec.Path))
+	if err != nil {
+		return "", fmt.Errorf("reading %s at %s from %s: %w", spec.Path, ref, spec.Repo, err)
+	}
+
+	return content, nil
+}
+
+// git runs git in the dir with the envvars added, which unlike the args are neither logged nor visible to `ps`
+func (p *Tracker) git(ctx context.Context, env map[string]string, dir string, args ...string) (string, error) {
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	stdout, stderr, err := p.cmdSite.SetEnv(env).CaptureStringsContext(ctx, "git", args)
+	if len(stderr) > 0 {
+		p.Logger.V(1).Info(stderr)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return stdout, nil
+}
+
+// gitAuthEnv returns the envvars that make git send the token in the `Authorization` header over HTTPS.
+// The header is set via GIT_CONFIG_* rather than the repository URL or `-c` so that the token never appears in the args.
+func gitAuthEnv(repo, token string) (map[string]string, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("gitFile: token auth is supported only for http(s) repositories: %s", repo)
+	}
+
+	credential := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+
+	return map[string]string{
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "http.extraHeader",
+		"GIT_CONFIG_VALUE_0": "Authorization: Basic " + credential,
+	}, nil
+}
+
+var gitVersionPattern = regexp.MustCompile(`git version (\d+)\.(\d+)`)
+
+// checkGitVersionForEnvConfig fails unless the output of `git version` is of git 2.31 or later,
+// as the older ones silently ignore the config in GIT_CONFIG_* envvars, and the token with it
+func checkGitVersionForEnvConfig(out string) error {
+	m := gitVersionPattern.FindStringSubmatch(out)
+	if m == nil {
+		return fmt.Errorf("gitFile: unexpected output of git version: %q", strings.TrimSpace(out))
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+
+	if major < 2 || (major == 2 && minor < 31) {
+		return fmt.Errorf("gitFile: token auth requires git 2.31 or later, but found %q", strings.TrimSpace(out))
+	}
+
+	return nil
+}
+
+func versionsFromPattern(content, pattern string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs []string
+
+	for _, m := range re.FindAllStringSubmatch(content, -1) {
+		if len(m) > 1 {
+			vs = append(vs, m[1])
+		} else {
+			vs = append(vs, m[0])
+		}
+	}
+
+	if len(vs) == 0 {
+		return nil, fmt.Errorf("pattern %q matched nothing", pattern)
+	}
+
+	return vs, nil
+}
@@ -0,0 +1,95 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type gitTagsProvider struct {
+	spec GitTags
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &gitTagsProvider{}
+var _ ContextReleaseProvider = &gitTagsProvider{}
+
+func newGitTagsProvider(spec GitTags, r *Tracker) *gitTagsProvider {
+	return &gitTagsProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *gitTagsProvider) sourceURL() string {
+	url, err := p.repoURL()
+	if err != nil {
+		return ""
+	}
+
+	return url
+}
+
+func (p *gitTagsProvider) All() ([]*Release, error) {
+	return p.AllContext(context.Background())
+}
+
+func (p *gitTagsProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	url, err := p.repoURL()
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := p.runtime.exec(ctx, "git", []string{"ls-remote", "--tags", url})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.runtime.versionsToReleases(tagsFromLsRemote(lines))
+}
+
+// repoURL returns the URL passed to `git ls-remote`, which is URL as-is when set
+func (p *gitTagsProvider) repoURL() (string, error) {
+	if p.spec.URL != "" {
+		return p.spec.URL, nil
+	}
+
+	return gitTagsRepoURL(normalizeGitSource(p.spec.Source), p.spec.Protocol)
+}
+
+// gitTagsRepoURL returns the URL of the repository like `github.com/mumoshu/variant` for the protocol,
+// which is one of `https`(default), `ssh` and `git`
+func gitTagsRepoURL(source, protocol string) (string, error) {
+	switch protocol {
+	case "", "https":
+		return fmt.Sprintf("https://%s.git", source), nil
+	case "ssh":
+		hostAndPath := strings.SplitN(source, "/", 2)
+		if len(hostAndPath) != 2 {
+			return "", fmt.Errorf("gitTags source must be in the form of HOST/PATH for the ssh protocol: %s", source)
+		}
+		return fmt.Sprintf("git@%s:%s.git", hostAndPath[0], hostAndPath[1]), nil
+	case "git":
+		return fmt.Sprintf("git://%s.git", source), nil
+	}
+
+	return "", fmt.Errorf("unsupported gitTags protocol %q: must be one of https, ssh and git", protocol)
+}
+
+// tagsFromLsRemote extracts the tag names out of the lines of `git ls-remote --tags` like `<sha>\trefs/tags/v1.0.0`.
+// The peeled refs of annotated tags, like `refs/tags/v1.0.0^{}`, are skipped as they duplicate the tags.
+func tagsFromLsRemote(lines []string) []string {
+	var tags []string
+
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 2 || strings.HasSuffix(fields[1], "^{}") {
+			continue
+		}
+
+		tags = append(tags, strings.TrimPrefix(fields[1], "refs/tags/"))
+	}
+
+	return tags
+}
@@ -0,0 +1,75 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/variantdev/mod/pkg/cmdsite"
+	"testing"
+)
+
+func TestGitTagsRepoURL(t *testing.T) {
+	testcases := []struct {
+		protocol string
+		expected string
+	}{
+		{protocol: "", expected: "https://github.com/mumoshu/variant.git"},
+		{protocol: "https", expected: "https://github.com/mumoshu/variant.git"},
+		{protocol: "ssh", expected: "git@github.com:mumoshu/variant.git"},
+		{protocol: "git", expected: "git://github.com/mumoshu/variant.git"},
+	}
+
+	for _, tc := range testcases {
+		got, err := gitTagsRepoURL("github.com/mumoshu/variant", tc.protocol)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("unexpected url for protocol %q: expected=%s, got=%s", tc.protocol, tc.expected, got)
+		}
+	}
+
+	if _, err := gitTagsRepoURL("github.com/mumoshu/variant", "ftp"); err == nil {
+		t.Error("expected error for an unsupported protocol, got none")
+	}
+}
+
+func TestTagsFromLsRemote(t *testing.T) {
+	lines := []string{
+		"1eb0008a5e2f85897de67b80a939e12495e1e2dc\trefs/tags/v0.1.0",
+		"cbdbe566564c323032c02c1a838358a314af63b4\trefs/tags/v0.2.0",
+		"9e8675140b5bc23da9630662c6a65aedcda20591\trefs/tags/v0.2.0^{}",
+		"38f891b56474ebe962e3804fbd29e7f4db8d220b\trefs/tags/charts/v0.3.0",
+		"warning: redirecting to https://github.com/mumoshu/variant.git/",
+	}
+
+	// Unlike `cut -d'/' -f 3`, the tag with slashes is kept as a whole, which VersionCapture can extract the version from
+	expected := []string{"v0.1.0", "v0.2.0", "charts/v0.3.0"}
+
+	if d := cmp.Diff(expected, tagsFromLsRemote(lines)); d != "" {
+		t.Errorf("%s", d)
+	}
+}
+
+func TestProvider_GitTags_URL(t *testing.T) {
+	for _, url := range []string{"ssh://git@bitbucket.org/myorg/myrepo.git", "git@gitlab.example.com:myorg/myrepo.git"} {
+		cmdr := cmdsite.NewTester(map[cmdsite.CommandInput]cmdsite.CommandOutput{
+			cmdsite.NewInput("git", []string{"ls-remote", "--tags", url}, map[string]string{}): {
+				Stdout: "1eb0008a5e2f85897de67b80a939e12495e1e2dc\trefs/tags/v1.0.0\ncbdbe566564c323032c02c1a838358a314af63b4\trefs/tags/v1.1.0\n",
+			},
+		})
+
+		tracker, err := New(Spec{VersionsFrom: VersionsFrom{GitTags: GitTags{URL: url, Protocol: "https"}}}, Commander(cmdr))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "1.1.0" {
+			t.Errorf("%s: unexpected version: expected=1.1.0, got=%s", url, latest.Version)
+		}
+	}
+}
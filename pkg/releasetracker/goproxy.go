@@ -0,0 +1,140 @@
+package releasetracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const DefaultGoProxy = "https://proxy.golang.org"
+
+type goProxyProvider struct {
+	spec GoProxy
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &goProxyProvider{}
+
+func newGoProxyProvider(spec GoProxy, r *Tracker) *goProxyProvider {
+	return &goProxyProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *goProxyProvider) All() ([]*Release, error) {
+	if p.spec.LatestOnly {
+		return p.latest()
+	}
+
+	return p.list()
+}
+
+// list reads all the versions of the module from `@v/list`, which returns newline-separated versions.
+// It falls back to `@latest` when the list is empty, which is the case for modules with pseudo-versions only.
+func (p *goProxyProvider) list() ([]*Release, error) {
+	res, err := p.runtime.httpGet(p.url("@v/list"))
+	if err != nil {
+		return nil, err
+	}
+
+	vs := []string{}
+
+	for _, l := range strings.Split(res, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			vs = append(vs, l)
+		}
+	}
+
+	if len(vs) == 0 {
+		p.runtime.Logger.V(1).Info("no versions listed, falling back to @latest", "module", p.spec.Module)
+		return p.latest()
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
+
+// latest resolves the newest version of the module with a single request to `@latest`
+func (p *goProxyProvider) latest() ([]*Release, error) {
+	res, err := p.runtime.httpGet(p.url("@latest"))
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Version string
+		Time    string
+	}
+
+	if err := json.Unmarshal([]byte(res), &info); err != nil {
+		return nil, fmt.Errorf("parsing @latest of go module %s: %v", p.spec.Module, err)
+	}
+
+	rs, err := p.runtime.versionsToReleases([]string{info.Version})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("go module %s: invalid version %q returned by @latest", p.spec.Module, info.Version)
+	}
+
+	if info.Time != "" {
+		publishedAt, err := time.Parse(time.RFC3339, info.Time)
+		if err != nil {
+			p.runtime.Logger.Info("Ignoring error: parsing the publish date", "error", err.Error(), "value", info.Time, "version", info.Version)
+		} else {
+			rs[0].PublishedAt = publishedAt
+		}
+	}
+
+	return rs, nil
+}
+
+func (p *goProxyProvider) latestRelease(_ context.Context) (*Release, bool, error) {
+	rs, err := p.latest()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rs[0], true, nil
+}
+
+func (p *goProxyProvider) sourceURL() string {
+	if p.spec.LatestOnly {
+		return p.url("@latest")
+	}
+
+	return p.url("@v/list")
+}
+
+func (p *goProxyProvider) url(path string) string {
+	proxy := p.spec.Proxy
+	if proxy == "" {
+		proxy = DefaultGoProxy
+	}
+
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(proxy, "/"), escapeModulePath(p.spec.Module), path)
+}
+
+// escapeModulePath escapes upper-case letters in the module path as `!` followed by the lower-case letter,
+// as the module proxy protocol requires. For example, `github.com/Azure/azure-sdk-for-go` becomes
+// `github.com/!azure/azure-sdk-for-go`.
+func escapeModulePath(path string) string {
+	var b strings.Builder
+
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteRune('!')
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
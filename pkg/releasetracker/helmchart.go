@@ -0,0 +1,49 @@
+package releasetracker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type helmChartProvider struct {
+	spec HelmChart
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &helmChartProvider{}
+
+func newHelmChartProvider(spec HelmChart, r *Tracker) *helmChartProvider {
+	return &helmChartProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *helmChartProvider) All() ([]*Release, error) {
+	if p.spec.Chart == "" {
+		return nil, fmt.Errorf("helmChart: chart must be specified")
+	}
+
+	rs, err := p.runtime.releasesFromGetterJsonPath(GetterJSONPath{
+		Source:   p.sourceURL(),
+		Versions: helmChartVersionsPath(p.spec.Chart),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("helmChart: chart %s in %s: %w", p.spec.Chart, p.spec.Repo, err)
+	}
+
+	return rs, nil
+}
+
+// sourceURL returns the URL of the repository index
+func (p *helmChartProvider) sourceURL() string {
+	return strings.TrimSuffix(p.spec.Repo, "/") + "/index.yaml"
+}
+
+// helmChartVersionsPath returns the jsonpath to the versions of the chart in the repository index.
+// The chart name is quoted as it can contain characters like `.` that can't be used in a dot-notated jsonpath.
+func helmChartVersionsPath(chart string) string {
+	return fmt.Sprintf("$.entries[%s][*].version", strconv.Quote(chart))
+}
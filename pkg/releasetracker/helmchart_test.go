@@ -0,0 +1,50 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/twpayne/go-vfs/vfst"
+	"testing"
+)
+
+func TestProvider_HelmChart(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/charts/index.yaml": `apiVersion: v1
+entries:
+  my.chart:
+  - name: my.chart
+    version: 1.2.0
+  - name: my.chart
+    version: 1.10.0
+  other:
+  - name: other
+    version: 3.0.0
+`,
+	}
+
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	spec := Spec{VersionsFrom: VersionsFrom{HelmChart: HelmChart{Repo: "/work/charts/", Chart: "my.chart"}}}
+
+	tracker, err := New(spec, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.2.0", "1.10.0"}, vs); d != "" {
+		t.Error(d)
+	}
+}
@@ -0,0 +1,44 @@
+package releasetracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+type helmOCIProvider struct {
+	spec HelmOCI
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &helmOCIProvider{}
+
+func newHelmOCIProvider(spec HelmOCI, r *Tracker) *helmOCIProvider {
+	return &helmOCIProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *helmOCIProvider) All() ([]*Release, error) {
+	registryURL, chart := registryRepository(p.spec.Registry, p.spec.Chart)
+
+	password, err := p.runtime.resolveSecret(p.spec.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := p.runtime.registryTags(registryURL, chart, p.spec.Username, password)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags of chart %s in %s: %w", chart, registryURL, err)
+	}
+
+	vs := make([]string, 0, len(tags))
+
+	for _, t := range tags {
+		// Helm replaces `+` in chart versions with `_` when pushing them as OCI tags, because `+` is not allowed in tags
+		vs = append(vs, strings.Replace(t, "_", "+", 1))
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
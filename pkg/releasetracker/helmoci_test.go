@@ -0,0 +1,64 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProvider_HelmOCI(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "myuser" || pass != "mypass" || r.URL.Query().Get("scope") != "repository:charts/mychart:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprint(w, `{"token": "oci-token"}`)
+		case "/v2/charts/mychart/tags/list":
+			if r.Header.Get("Authorization") != "Bearer oci-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry.example.com",scope="repository:charts/mychart:pull"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"name": "charts/mychart", "tags": ["0.1.0", "0.2.0-rc.1", "0.2.0_build.1", "latest"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	defer setenv(t, "TEST_REGISTRY_PASSWORD", "mypass")()
+
+	spec := Spec{VersionsFrom: VersionsFrom{HelmOCI: HelmOCI{
+		Registry: srv.URL + "/charts",
+		Chart:    "mychart",
+		Username: "myuser",
+		Password: "env:TEST_REGISTRY_PASSWORD",
+	}}}
+
+	tracker, err := New(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"0.1.0", "0.2.0-rc.1", "0.2.0+build.1"}, got); d != "" {
+		t.Errorf("%s", d)
+	}
+}
@@ -0,0 +1,105 @@
+package releasetracker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/twpayne/go-vfs"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// httpCachePrefix prefixes the names of the cache entries of HTTP responses,
+// each of which is a top-level entry in the cache directory so that CacheStats and PruneCache see them
+const httpCachePrefix = "http-"
+
+// httpGetCachedContext is httpGetContext that serves the response from the disk cache while it is fresher than the TTL
+// set with CacheTTL. Only successful responses are cached. The cache is keyed by the URL.
+func (p *Tracker) httpGetCachedContext(ctx context.Context, url string, opts ...vhttpget.Option) (*vhttpget.Response, error) {
+	if p.httpCacheTTL <= 0 {
+		return p.httpGetContext(ctx, url, opts...)
+	}
+
+	path := p.httpCachePath(url)
+
+	if res, ok := p.readHTTPCache(path); ok {
+		p.Logger.V(1).Info("using cached http response", "url", url, "path", path)
+		return res, nil
+	}
+
+	res, err := p.httpGetContext(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == 0 || (res.StatusCode >= 200 && res.StatusCode < 300) {
+		if err := p.writeHTTPCache(path, res); err != nil {
+			p.Logger.V(1).Info("ignoring error: writing http cache", "url", url, "path", path, "error", err.Error())
+		}
+	}
+
+	return res, nil
+}
+
+func (p *Tracker) httpCachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+
+	return filepath.Join(p.cacheDir, httpCachePrefix+hex.EncodeToString(sum[:8]), "response.json")
+}
+
+func (p *Tracker) readHTTPCache(path string) (*vhttpget.Response, bool) {
+	info, err := p.fs.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > p.httpCacheTTL {
+		return nil, false
+	}
+
+	bs, err := p.fs.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var res vhttpget.Response
+	if err := json.Unmarshal(bs, &res); err != nil {
+		p.Logger.V(1).Info("ignoring broken http cache", "path", path, "error", err.Error())
+		return nil, false
+	}
+
+	return &res, true
+}
+
+func (p *Tracker) writeHTTPCache(path string, res *vhttpget.Response) error {
+	bs, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	if err := vfs.MkdirAll(p.fs, filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return p.fs.WriteFile(path, bs, 0644)
+}
+
+// InvalidateCache removes all the cached HTTP responses, so that the next fetch hits the network.
+func (p *Tracker) InvalidateCache() error {
+	entries, err := p.cacheEntries()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), httpCachePrefix) {
+			continue
+		}
+
+		if err := p.fs.RemoveAll(filepath.Join(p.cacheDir, e.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"time"
+)
+
+// httpGetContext is httpGetOnceContext that retries on network errors and 5xx and 429 responses,
+// up to the number of retries set with HTTPRetries, doubling the wait on each retry.
+// Other responses, including 4xx, are returned as-is.
+func (p *Tracker) httpGetContext(ctx context.Context, url string, opts ...vhttpget.Option) (*vhttpget.Response, error) {
+	wait := p.httpRetryBackoff
+
+	for i := 0; ; i++ {
+		res, err := p.httpGetOnceContext(ctx, url, opts...)
+
+		retryable := (err != nil && ctx.Err() == nil) || (err == nil && isRetryableStatus(res.StatusCode))
+		if !retryable || i >= p.httpRetries {
+			if err == nil && retryable && p.httpRetries > 0 {
+				return nil, fmt.Errorf("getting %s: %s after %d retries", url, http.StatusText(res.StatusCode), p.httpRetries)
+			}
+
+			return res, err
+		}
+
+		if err != nil {
+			p.Logger.V(1).Info("retrying http get", "url", url, "attempt", i+1, "wait", wait.String(), "error", err.Error())
+		} else {
+			p.Logger.V(1).Info("retrying http get", "url", url, "attempt", i+1, "wait", wait.String(), "status", res.StatusCode)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("getting %s: %w", url, ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	return code >= 500 || code == http.StatusTooManyRequests
+}
@@ -0,0 +1,79 @@
+package releasetracker
+
+import (
+	"errors"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// flakyGetter responds to each request with the next response, or the error when the response is nil
+type flakyGetter struct {
+	responses []*vhttpget.Response
+	calls     int
+}
+
+func (g *flakyGetter) DoRequest(url string, opt ...vhttpget.Option) (string, error) {
+	res, err := g.Get(url, opt...)
+	if err != nil {
+		return "", err
+	}
+	return res.Body, nil
+}
+
+func (g *flakyGetter) Get(url string, opt ...vhttpget.Option) (*vhttpget.Response, error) {
+	res := g.responses[g.calls]
+	g.calls++
+	if res == nil {
+		return nil, errors.New("connection reset by peer")
+	}
+	return res, nil
+}
+
+func TestTracker_HTTPRetries(t *testing.T) {
+	ok := &vhttpget.Response{StatusCode: http.StatusOK, Body: `[{"version": "1.0.0"}]`}
+	unavailable := &vhttpget.Response{StatusCode: http.StatusServiceUnavailable, Body: `unavailable`}
+	tooMany := &vhttpget.Response{StatusCode: http.StatusTooManyRequests, Body: `slow down`}
+	notFound := &vhttpget.Response{StatusCode: http.StatusNotFound, Body: `[]`}
+
+	testcases := []struct {
+		name      string
+		responses []*vhttpget.Response
+		retries   int
+		calls     int
+		wantErr   bool
+	}{
+		{name: "5xx", responses: []*vhttpget.Response{unavailable, ok}, retries: 2, calls: 2},
+		{name: "429 and network error", responses: []*vhttpget.Response{tooMany, nil, ok}, retries: 2, calls: 3},
+		{name: "4xx", responses: []*vhttpget.Response{notFound, ok}, retries: 2, calls: 1, wantErr: true},
+		{name: "exhausted", responses: []*vhttpget.Response{unavailable, unavailable}, retries: 1, calls: 2, wantErr: true},
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      "https://api.example.com/releases",
+		Versions: "$[*].version",
+	}}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &flakyGetter{responses: tc.responses}
+
+			tracker, err := New(spec, HttpGetter(getter), HTTPRetries(tc.retries, time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = tracker.GetReleases()
+			if tc.wantErr && err == nil {
+				t.Error("expected error, got none")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if getter.calls != tc.calls {
+				t.Errorf("unexpected number of requests: expected=%d, got=%d", tc.calls, getter.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/itchyny/gojq"
+)
+
+// evalJQ runs the jq query against the value, for transforms JSONPath can't express, like `[.[] | "v" + .version]`.
+// The results of the query emitting two or more values, like `.[].version`, are collected into an array.
+func evalJQ(query string, v interface{}) (interface{}, error) {
+	q, err := gojq.Parse(query)
+	if err != nil {
+		return nil, fmt.Errorf("parsing jq %q: %v", query, err)
+	}
+
+	var results []interface{}
+
+	iter := q.Run(v)
+	for {
+		r, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if err, ok := r.(error); ok {
+			return nil, fmt.Errorf("jq %q: %v", query, err)
+		}
+
+		results = append(results, r)
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+
+	if results == nil {
+		results = []interface{}{}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,25 @@
+package releasetracker
+
+import (
+	"context"
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+)
+
+// jsonpathLanguage is JSONPath extended with the full gval expression language, so that filter expressions
+// can use comparison (`==`, `!=`, `<`, `>`, `<=`, `>=`), logical (`&&`, `||`, `!`), and regexp match (`=~`) operators,
+// like `$[?(@.stable == true && @.channel != "lts")].version`.
+var jsonpathLanguage = gval.Full(jsonpath.Language())
+
+// evalJSONPath evaluates the JSONPath expression against the value.
+//
+// On top of the standard JSONPath syntax like `$.releases[*].version` and `$..version`,
+// the expression can contain filters like `$[?(@.stable == true)].version`.
+func evalJSONPath(path string, v interface{}) (interface{}, error) {
+	eval, err := jsonpathLanguage.NewEvaluable(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return eval(context.Background(), v)
+}
@@ -0,0 +1,209 @@
+package releasetracker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/url"
+	"strings"
+)
+
+// KVReader reads values out of a key-value store like Consul or etcd.
+type KVReader interface {
+	// Get returns the value stored at the key, or the values of all the keys under the key when prefix is true.
+	Get(key string, prefix bool) ([]string, error)
+}
+
+type kvProvider struct {
+	kv     KVReader
+	key    string
+	prefix bool
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &kvProvider{}
+
+func (p *kvProvider) All() ([]*Release, error) {
+	values, err := p.kv.Get(p.key, p.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var vs []string
+
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			vs = append(vs, v)
+		}
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
+
+func newConsulKVProvider(spec ConsulKV, r *Tracker) *kvProvider {
+	kv := r.kvReader
+	if kv == nil {
+		kv = &consulKV{spec: spec, runtime: r}
+	}
+
+	return &kvProvider{
+		kv:      kv,
+		key:     spec.Key,
+		prefix:  spec.Prefix,
+		runtime: r,
+	}
+}
+
+func newEtcdKVProvider(spec EtcdKV, r *Tracker) *kvProvider {
+	kv := r.kvReader
+	if kv == nil {
+		kv = &etcdKV{spec: spec, runtime: r}
+	}
+
+	return &kvProvider{
+		kv:      kv,
+		key:     spec.Key,
+		prefix:  spec.Prefix,
+		runtime: r,
+	}
+}
+
+func kvAddress(addr, defaultAddr string) string {
+	if addr == "" {
+		return defaultAddr
+	}
+
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+
+	return strings.TrimSuffix(addr, "/")
+}
+
+type consulKV struct {
+	spec    ConsulKV
+	runtime *Tracker
+}
+
+func (c *consulKV) Get(key string, prefix bool) ([]string, error) {
+	u := fmt.Sprintf("%s/v1/kv/%s", kvAddress(c.spec.Address, "http://127.0.0.1:8500"), strings.TrimPrefix(key, "/"))
+
+	if prefix {
+		u += "?recurse=true"
+	}
+
+	token, err := c.runtime.resolveSecret(c.spec.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token is sent in the header rather than the query, as the URL is logged
+	var opts []vhttpget.Option
+	if token != "" {
+		opts = append(opts, vhttpget.Header("X-Consul-Token", token))
+	}
+
+	res, err := c.runtime.httpGet(u, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(res) == "" {
+		return nil, nil
+	}
+
+	var entries []struct {
+		Key   string
+		Value string
+	}
+
+	if err := json.Unmarshal([]byte(res), &entries); err != nil {
+		return nil, fmt.Errorf("parsing consul kv response: %v", err)
+	}
+
+	var vs []string
+
+	for _, e := range entries {
+		v, err := base64.StdEncoding.DecodeString(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("decoding value of consul key %q: %v", e.Key, err)
+		}
+
+		vs = append(vs, string(v))
+	}
+
+	return vs, nil
+}
+
+type etcdKV struct {
+	spec    EtcdKV
+	runtime *Tracker
+}
+
+type etcdNode struct {
+	Key   string     `json:"key"`
+	Value string     `json:"value"`
+	Dir   bool       `json:"dir"`
+	Nodes []etcdNode `json:"nodes"`
+}
+
+func (n etcdNode) values() []string {
+	if !n.Dir {
+		return []string{n.Value}
+	}
+
+	var vs []string
+
+	for _, c := range n.Nodes {
+		vs = append(vs, c.values()...)
+	}
+
+	return vs
+}
+
+func (c *etcdKV) Get(key string, prefix bool) ([]string, error) {
+	addr, err := url.Parse(kvAddress(c.spec.Address, "http://127.0.0.1:2379"))
+	if err != nil {
+		return nil, err
+	}
+
+	// The credentials are sent in the header rather than the userinfo of the URL, as the URL is logged
+	var opts []vhttpget.Option
+
+	if c.spec.Username != "" {
+		password, err := c.runtime.resolveSecret(c.spec.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		cred := base64.StdEncoding.EncodeToString([]byte(c.spec.Username + ":" + password))
+		opts = append(opts, vhttpget.Header("Authorization", "Basic "+cred))
+	}
+
+	u := fmt.Sprintf("%s/v2/keys/%s", addr.String(), strings.TrimPrefix(key, "/"))
+	if prefix {
+		u += "?recursive=true"
+	}
+
+	res, err := c.runtime.httpGet(u, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Node etcdNode `json:"node"`
+	}
+
+	if err := json.Unmarshal([]byte(res), &body); err != nil {
+		return nil, fmt.Errorf("parsing etcd response: %v", err)
+	}
+
+	if !prefix && body.Node.Dir {
+		return nil, fmt.Errorf("etcd key %q is a directory: set prefix to read all the keys under it", key)
+	}
+
+	return body.Node.values(), nil
+}
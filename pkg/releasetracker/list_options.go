@@ -0,0 +1,36 @@
+package releasetracker
+
+// ListOption customizes the releases returned by Releases
+type ListOption interface {
+	SetListOption(o *listOptions)
+}
+
+type listOptions struct {
+	descending bool
+	constraint string
+}
+
+// Descending makes Releases return the newest release first
+func Descending() ListOption {
+	return &descendingOption{}
+}
+
+type descendingOption struct {
+}
+
+func (s *descendingOption) SetListOption(o *listOptions) {
+	o.descending = true
+}
+
+// Constraint makes Releases return only the releases satisfying the semver constraint, as Latest does
+func Constraint(c string) ListOption {
+	return &constraintOption{c: c}
+}
+
+type constraintOption struct {
+	c string
+}
+
+func (s *constraintOption) SetListOption(o *listOptions) {
+	o.constraint = s.c
+}
@@ -0,0 +1,118 @@
+package releasetracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMavenRepository = "https://search.maven.org"
+
+	// DefaultMavenRows is the number of versions requested per page from the search API
+	DefaultMavenRows = 200
+)
+
+type mavenProvider struct {
+	spec MavenArtifact
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &mavenProvider{}
+
+func newMavenProvider(spec MavenArtifact, r *Tracker) *mavenProvider {
+	return &mavenProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *mavenProvider) sourceURL() string {
+	return p.pageURL(0)
+}
+
+// pageURL returns the URL of the page of the Solr search API starting at the offset
+func (p *mavenProvider) pageURL(start int) string {
+	repo := p.spec.Repository
+	if repo == "" {
+		repo = DefaultMavenRepository
+	}
+
+	q := url.Values{}
+	q.Set("q", fmt.Sprintf(`g:"%s" AND a:"%s"`, p.spec.GroupID, p.spec.ArtifactID))
+	q.Set("core", "gav")
+	q.Set("rows", strconv.Itoa(DefaultMavenRows))
+	q.Set("start", strconv.Itoa(start))
+	q.Set("wt", "json")
+
+	return fmt.Sprintf("%s/solrsearch/select?%s", strings.TrimSuffix(repo, "/"), q.Encode())
+}
+
+// All reads the versions of the artifact from `$.response.docs[*].v`, requesting the pages with the `start` parameter
+// until all the `numFound` versions are read.
+func (p *mavenProvider) All() ([]*Release, error) {
+	if p.spec.GroupID == "" || p.spec.ArtifactID == "" {
+		return nil, fmt.Errorf("mavenArtifact: groupID and artifactID must be specified")
+	}
+
+	var vs []string
+
+	publishedAt := map[string]time.Time{}
+
+	for start, pages := 0, 0; ; pages++ {
+		if pages >= DefaultMaxPages {
+			p.runtime.Logger.V(1).Info("reached the max number of pages. ignoring remaining pages", "maxPages", DefaultMaxPages, "start", start)
+			break
+		}
+
+		u := p.pageURL(start)
+
+		res, err := p.runtime.httpGet(u)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Response struct {
+				NumFound int `json:"numFound"`
+				Docs     []struct {
+					V         string `json:"v"`
+					Timestamp int64  `json:"timestamp"`
+				} `json:"docs"`
+			} `json:"response"`
+		}
+
+		if err := json.Unmarshal([]byte(res), &page); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", u, err)
+		}
+
+		for _, d := range page.Response.Docs {
+			vs = append(vs, d.V)
+
+			if d.Timestamp > 0 {
+				publishedAt[strings.TrimPrefix(d.V, "v")] = time.Unix(0, d.Timestamp*int64(time.Millisecond)).UTC()
+			}
+		}
+
+		start += len(page.Response.Docs)
+
+		if len(page.Response.Docs) == 0 || start >= page.Response.NumFound {
+			break
+		}
+	}
+
+	rs, err := p.runtime.versionsToReleases(vs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rs {
+		r.PublishedAt = publishedAt[r.Version]
+	}
+
+	return rs, nil
+}
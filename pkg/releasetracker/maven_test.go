@@ -0,0 +1,48 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"testing"
+	"time"
+)
+
+func TestProvider_MavenArtifact(t *testing.T) {
+	page := "https://search.maven.org/solrsearch/select?core=gav&q=g%3A%22org.example%22+AND+a%3A%22lib%22&rows=200&start="
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: page + "0&wt=json"}: `{"response": {"numFound": 3, "start": 0, "docs": [
+  {"g": "org.example", "a": "lib", "v": "1.2.0", "timestamp": 1617278400000},
+  {"g": "org.example", "a": "lib", "v": "1.10.0", "timestamp": 1633089600000}
+]}}`,
+		vhttpget.TestGetInput{URL: page + "2&wt=json"}: `{"response": {"numFound": 3, "start": 2, "docs": [
+  {"g": "org.example", "a": "lib", "v": "1.1.0"}
+]}}`,
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{MavenArtifact: MavenArtifact{GroupID: "org.example", ArtifactID: "lib"}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.1.0", "1.2.0", "1.10.0"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	expected := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	if latest := rs[len(rs)-1]; !latest.PublishedAt.Equal(expected) {
+		t.Errorf("unexpected publish date: expected=%v, got=%v", expected, latest.PublishedAt)
+	}
+}
@@ -0,0 +1,214 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-logr/logr"
+	"sync"
+	"time"
+)
+
+// Middleware wraps a ReleaseProvider to add a cross-cutting concern like caching, retrying, or logging.
+//
+// Middlewares are enabled via the ProviderMiddlewares option, and composed around the provider returned by GetProvider.
+// Return a ProviderContextFunc that calls AllContext of the wrapped provider, so that cancelling the context
+// stops the in-flight fetch rather than abandoning it.
+type Middleware func(ReleaseProvider) ReleaseProvider
+
+// ProviderFunc adapts an ordinary function to ReleaseProvider.
+// Use ProviderContextFunc for a function that can stop fetching once the context is done.
+type ProviderFunc func() ([]*Release, error)
+
+func (f ProviderFunc) All() ([]*Release, error) {
+	return f()
+}
+
+// ProviderContextFunc adapts an ordinary function to ContextReleaseProvider
+type ProviderContextFunc func(ctx context.Context) ([]*Release, error)
+
+var _ ContextReleaseProvider = ProviderContextFunc(nil)
+
+func (f ProviderContextFunc) All() ([]*Release, error) {
+	return f(context.Background())
+}
+
+func (f ProviderContextFunc) AllContext(ctx context.Context) ([]*Release, error) {
+	return f(ctx)
+}
+
+// composeMiddlewares wraps the provider with the middlewares. The first middleware becomes the outermost.
+func composeMiddlewares(pp ReleaseProvider, mws []Middleware) ReleaseProvider {
+	for i := len(mws) - 1; i >= 0; i-- {
+		pp = mws[i](pp)
+	}
+	return pp
+}
+
+// WithRetry retries fetching releases up to `attempts` times in total on errors.
+// The wait between attempts starts from `backoff` and doubles on each retry. It stops waiting once the context is done.
+func WithRetry(attempts int, backoff time.Duration) Middleware {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return func(next ReleaseProvider) ReleaseProvider {
+		return ProviderContextFunc(func(ctx context.Context) ([]*Release, error) {
+			wait := backoff
+
+			var rs []*Release
+			var err error
+
+			for i := 0; i < attempts; i++ {
+				if i > 0 {
+					timer := time.NewTimer(wait)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return nil, fmt.Errorf("retrying after %v: %w", err, ctx.Err())
+					case <-timer.C:
+					}
+					wait *= 2
+				}
+
+				rs, err = allContext(ctx, next)
+				if err == nil {
+					return rs, nil
+				}
+			}
+
+			return nil, err
+		})
+	}
+}
+
+// WithCache caches the releases fetched by the provider in memory for `ttl`. A non-positive ttl makes the cache never expire.
+// Errors and empty results are never cached.
+//
+// The cache is shared by all the providers wrapped by the same middleware, so that the cache survives across
+// Tracker.GetReleases calls. The releases are cached per versions source, so that a fallback never gets the ones
+// of the primary source or the other fallbacks.
+func WithCache(ttl time.Duration) Middleware {
+	c := &memoryCache{ttl: ttl}
+
+	return func(next ReleaseProvider) ReleaseProvider {
+		return ProviderContextFunc(func(ctx context.Context) ([]*Release, error) {
+			return c.get(ctx, next)
+		})
+	}
+}
+
+// WithStaleCache is WithCache that keeps serving the cached releases after `ttl` when the provider fails,
+// as long as they are younger than `maxAge`. This keeps the tracker working during brief upstream outages,
+// while never serving releases fetched longer than `maxAge` ago. Once the cache exceeds `maxAge`, the provider's error is returned.
+//
+// Releases younger than `ttl` are served without calling the provider.
+func WithStaleCache(ttl, maxAge time.Duration) Middleware {
+	c := &memoryCache{ttl: ttl, maxAge: maxAge}
+
+	return func(next ReleaseProvider) ReleaseProvider {
+		return ProviderContextFunc(func(ctx context.Context) ([]*Release, error) {
+			return c.get(ctx, next)
+		})
+	}
+}
+
+type memoryCache struct {
+	ttl time.Duration
+
+	// maxAge is the age up to which the cached releases are served when the provider failed.
+	// Stale releases are never served when this is less than or equal to ttl.
+	maxAge time.Duration
+
+	// now is overridden in tests
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	releases  []*Release
+	fetchedAt time.Time
+}
+
+// get returns the releases cached for the versions source of the context, fetching them from the provider if needed
+func (c *memoryCache) get(ctx context.Context, next ReleaseProvider) ([]*Release, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+
+	key := sourceKeyFrom(ctx)
+
+	e := c.entries[key]
+
+	var age time.Duration
+	if e != nil {
+		age = now().Sub(e.fetchedAt)
+	}
+
+	if e != nil && (c.ttl <= 0 || age < c.ttl) {
+		return e.releases, nil
+	}
+
+	rs, err := allContext(ctx, next)
+	if err != nil {
+		if e != nil && age < c.maxAge {
+			return e.releases, nil
+		}
+		return nil, err
+	}
+
+	// An empty result is likely transient, and caching it would starve RetryOnEmpty and the fallbacks
+	if len(rs) == 0 {
+		return rs, nil
+	}
+
+	if c.entries == nil {
+		c.entries = map[string]*memoryCacheEntry{}
+	}
+
+	c.entries[key] = &memoryCacheEntry{releases: rs, fetchedAt: now()}
+
+	return rs, nil
+}
+
+type sourceKeyContextKey struct{}
+
+// withSourceKey returns the context telling the middlewares which versions source is being fetched
+func withSourceKey(ctx context.Context, vf VersionsFrom) context.Context {
+	// The fallbacks are fetched as sources of their own
+	vf.Fallbacks = nil
+
+	return context.WithValue(ctx, sourceKeyContextKey{}, fmt.Sprintf("%+v", vf))
+}
+
+// sourceKeyFrom returns the key of the versions source being fetched, which is empty for a provider fetched
+// outside the tracker, like the one returned by GetProvider
+func sourceKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(sourceKeyContextKey{}).(string)
+
+	return key
+}
+
+// WithLogging logs the outcome of every fetch at V(1)
+func WithLogging(logger logr.Logger) Middleware {
+	return func(next ReleaseProvider) ReleaseProvider {
+		return ProviderContextFunc(func(ctx context.Context) ([]*Release, error) {
+			start := time.Now()
+
+			rs, err := allContext(ctx, next)
+			if err != nil {
+				logger.V(1).Info("provider failed", "duration", time.Since(start), "error", err.Error())
+				return nil, err
+			}
+
+			logger.V(1).Info("provider succeeded", "duration", time.Since(start), "count", len(rs))
+
+			return rs, nil
+		})
+	}
+}
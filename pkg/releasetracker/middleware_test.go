@@ -0,0 +1,216 @@
+package releasetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Masterminds/semver"
+	"k8s.io/klog/klogr"
+	"testing"
+	"time"
+)
+
+// flakyProvider fails the first `failures` fetches, and returns the releases afterwards
+type flakyProvider struct {
+	failures int
+	releases []*Release
+
+	calls int
+}
+
+func (p *flakyProvider) All() ([]*Release, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return nil, fmt.Errorf("transient error %d", p.calls)
+	}
+	return p.releases, nil
+}
+
+func TestMiddleware_RetryAndCache(t *testing.T) {
+	base := &flakyProvider{
+		failures: 2,
+		releases: []*Release{{Semver: semver.MustParse("1.0.0"), Version: "1.0.0"}},
+	}
+
+	pp := composeMiddlewares(base, []Middleware{WithCache(time.Hour), WithRetry(3, time.Millisecond)})
+
+	for i := 0; i < 2; i++ {
+		rs, err := pp.All()
+		if err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+
+		if len(rs) != 1 || rs[0].Version != "1.0.0" {
+			t.Errorf("fetch %d: unexpected releases: %v", i, rs)
+		}
+	}
+
+	if base.calls != 3 {
+		t.Errorf("unexpected number of calls to the base provider: expected=3, got=%d", base.calls)
+	}
+}
+
+func TestMiddleware_RetryExhausted(t *testing.T) {
+	base := &flakyProvider{failures: 5}
+
+	pp := WithRetry(3, 0)(base)
+
+	_, err := pp.All()
+	if err == nil || err.Error() != "transient error 3" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if base.calls != 3 {
+		t.Errorf("unexpected number of calls to the base provider: expected=3, got=%d", base.calls)
+	}
+}
+
+func TestTracker_ProviderMiddlewares(t *testing.T) {
+	base := &flakyProvider{releases: []*Release{{Semver: semver.MustParse("1.0.0"), Version: "1.0.0"}}}
+
+	tracker := newFakeExecTracker(t, Spec{}, "0.1.0\n", ProviderMiddlewares(
+		WithCache(0),
+		func(next ReleaseProvider) ReleaseProvider {
+			return base
+		},
+	))
+
+	for i := 0; i < 2; i++ {
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "1.0.0" {
+			t.Errorf("unexpected version: expected=1.0.0, got=%s", latest.Version)
+		}
+	}
+
+	if base.calls != 1 {
+		t.Errorf("unexpected number of calls to the base provider: expected=1, got=%d", base.calls)
+	}
+}
+
+func TestMiddleware_StaleCache(t *testing.T) {
+	base := &flakyProvider{releases: []*Release{{Semver: semver.MustParse("1.0.0"), Version: "1.0.0"}}}
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := &memoryCache{ttl: time.Minute, maxAge: time.Hour, now: func() time.Time { return now }}
+
+	pp := ProviderFunc(func() ([]*Release, error) {
+		return c.get(context.Background(), base)
+	})
+
+	if _, err := pp.All(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The upstream goes down from now on
+	base.failures = 100
+
+	testcases := []struct {
+		elapsed time.Duration
+		calls   int
+		err     bool
+	}{
+		// fresh: served without revalidation
+		{elapsed: 30 * time.Second, calls: 1},
+		// stale but servable: revalidation failed, but the cache is younger than maxAge
+		{elapsed: 30 * time.Minute, calls: 2},
+		// too stale: the upstream error is returned
+		{elapsed: 2 * time.Hour, calls: 3, err: true},
+	}
+
+	start := now
+
+	for _, tc := range testcases {
+		now = start.Add(tc.elapsed)
+
+		rs, err := pp.All()
+		if tc.err {
+			if err == nil {
+				t.Errorf("%v: expected error, got releases %v", tc.elapsed, rs)
+			}
+		} else if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.elapsed, err)
+		} else if len(rs) != 1 || rs[0].Version != "1.0.0" {
+			t.Errorf("%v: unexpected releases: %v", tc.elapsed, rs)
+		}
+
+		if base.calls != tc.calls {
+			t.Errorf("%v: unexpected number of calls to the base provider: expected=%d, got=%d", tc.elapsed, tc.calls, base.calls)
+		}
+	}
+}
+
+func TestTracker_RetryOnEmpty(t *testing.T) {
+	calls := 0
+
+	empty := func(next ReleaseProvider) ReleaseProvider {
+		return ProviderFunc(func() ([]*Release, error) {
+			calls++
+			if calls == 1 {
+				return nil, nil
+			}
+			return next.All()
+		})
+	}
+
+	for _, retry := range []bool{false, true} {
+		calls = 0
+
+		spec := Spec{RetryOnEmpty: retry, RetryOnEmptyBackoff: time.Millisecond}
+
+		tracker := newFakeExecTracker(t, spec, "1.0.0\n", ProviderMiddlewares(empty))
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := 0
+		if retry {
+			expected = 1
+		}
+
+		if len(rs) != expected {
+			t.Errorf("retryOnEmpty=%v: unexpected number of releases: expected=%d, got=%d", retry, expected, len(rs))
+		}
+
+		if attempts := len(tracker.LastFetchReport().Attempts); attempts != calls {
+			t.Errorf("retryOnEmpty=%v: unexpected number of attempts reported: expected=%d, got=%d", retry, calls, attempts)
+		}
+	}
+}
+
+func TestMiddleware_Context(t *testing.T) {
+	cancelled := make(chan struct{})
+
+	base := ProviderContextFunc(func(ctx context.Context) ([]*Release, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	pp := composeMiddlewares(base, []Middleware{WithLogging(klogr.New()), WithCache(time.Hour), WithRetry(3, time.Hour)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := allContext(ctx, pp); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the retry did not stop waiting on the deadline: took %v", elapsed)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("the context was not passed to the provider")
+	}
+}
@@ -0,0 +1,100 @@
+package releasetracker
+
+import (
+	"strings"
+)
+
+// trimRepoURL strips the leading scheme, the `user@` part of scp-like git URLs, and the trailing `/` and `.git`,
+// so that `https://github.com/org/repo.git` and `git@github.com:org/repo` both become `github.com/org/repo`.
+func trimRepoURL(s string) string {
+	s = strings.TrimSpace(s)
+
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	} else if at, colon := strings.Index(s, "@"), strings.Index(s, ":"); at >= 0 && colon > at {
+		// scp-like syntax, like `git@github.com:org/repo.git`
+		s = s[:colon] + "/" + s[colon+1:]
+	}
+
+	if i := strings.Index(s, "@"); i >= 0 && i < strings.Index(s+"/", "/") {
+		s = s[i+1:]
+	}
+
+	s = strings.TrimSuffix(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+
+	return s
+}
+
+// hasScheme reports whether s is a URL with a scheme, like `https://host/org/repo`, or a scp-like git URL, like `git@host:org/repo`
+func hasScheme(s string) bool {
+	s = strings.TrimSpace(s)
+
+	if strings.Contains(s, "://") {
+		return true
+	}
+
+	at, colon := strings.Index(s, "@"), strings.Index(s, ":")
+
+	return at >= 0 && colon > at
+}
+
+// trimHost turns `host/org/repo` or the URL of it into `org/repo`. Without a scheme, the first path segment is stripped
+// only when it is one of the hosts, so that dotted names like `my.group/project` are returned as-is.
+func trimHost(s string, hosts ...string) string {
+	explicit := hasScheme(s)
+
+	s = trimRepoURL(s)
+
+	segments := strings.SplitN(s, "/", 2)
+	if len(segments) != 2 {
+		return s
+	}
+
+	if explicit {
+		return segments[1]
+	}
+
+	for _, h := range hosts {
+		if h != "" && strings.EqualFold(segments[0], h) {
+			return segments[1]
+		}
+	}
+
+	return s
+}
+
+// normalizeGitHubSource turns a GitHub repository URL like `https://github.com/org/repo` into `org/repo`.
+// host is the one of the spec, whose `host/org/repo` is also accepted
+func normalizeGitHubSource(s, host string) string {
+	return trimHost(s, "github.com", host)
+}
+
+// normalizeGitLabSource turns a GitLab project URL like `https://gitlab.com/group/subgroup/project` into `group/subgroup/project`.
+// host is the one of the spec, whose `host/group/project` is also accepted
+func normalizeGitLabSource(s, host string) string {
+	return trimHost(s, "gitlab.com", host)
+}
+
+// normalizeGitSource turns a git repository URL like `https://github.com/org/repo.git` into `github.com/org/repo`
+func normalizeGitSource(s string) string {
+	return trimRepoURL(s)
+}
+
+// normalizeDockerHubSource turns a Docker Hub URL like `https://hub.docker.com/r/org/image` or
+// `https://hub.docker.com/_/image` into the repository name, like `org/image` or `library/image`
+func normalizeDockerHubSource(s string) string {
+	if !strings.Contains(s, "://") && !strings.HasPrefix(s, "hub.docker.com/") && !strings.HasPrefix(s, "docker.io/") {
+		return s
+	}
+
+	s = trimHost(s, "hub.docker.com", "docker.io")
+
+	if strings.HasPrefix(s, "r/") {
+		s = strings.TrimPrefix(s, "r/")
+	} else if strings.HasPrefix(s, "_/") {
+		s = "library/" + strings.TrimPrefix(s, "_/")
+	}
+
+	return s
+}
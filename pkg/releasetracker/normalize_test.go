@@ -0,0 +1,49 @@
+package releasetracker
+
+import (
+	"testing"
+)
+
+func TestNormalizeSources(t *testing.T) {
+	normalizeGitHubSource := func(s string) string { return normalizeGitHubSource(s, "") }
+	normalizeGitLabSource := func(s string) string { return normalizeGitLabSource(s, "gitlab.example.com") }
+
+	testcases := []struct {
+		normalize func(string) string
+		input     string
+		expected  string
+	}{
+		{normalizeGitHubSource, "mumoshu/variant", "mumoshu/variant"},
+		{normalizeGitHubSource, "https://github.com/mumoshu/variant", "mumoshu/variant"},
+		{normalizeGitHubSource, "https://github.com/mumoshu/variant.git", "mumoshu/variant"},
+		{normalizeGitHubSource, "https://github.com/mumoshu/variant/", "mumoshu/variant"},
+		{normalizeGitHubSource, "github.com/mumoshu/variant", "mumoshu/variant"},
+		{normalizeGitHubSource, "git@github.com:mumoshu/variant.git", "mumoshu/variant"},
+		{normalizeGitHubSource, "mumoshu/variant.js", "mumoshu/variant.js"},
+
+		{normalizeGitLabSource, "group/subgroup/project", "group/subgroup/project"},
+		{normalizeGitLabSource, "https://gitlab.com/group/subgroup/project.git", "group/subgroup/project"},
+		{normalizeGitLabSource, "gitlab.com/group/project", "group/project"},
+		{normalizeGitLabSource, "gitlab.example.com/group/project", "group/project"},
+		{normalizeGitLabSource, "git@gitlab.example.com:my.group/project.git", "my.group/project"},
+		{normalizeGitLabSource, "my.group/project", "my.group/project"},
+		{normalizeGitLabSource, "org.io/sub/project", "org.io/sub/project"},
+		{normalizeGitLabSource, "https://gitlab.example.com/org.io/sub/project", "org.io/sub/project"},
+
+		{normalizeGitSource, "github.com/mumoshu/variant", "github.com/mumoshu/variant"},
+		{normalizeGitSource, "https://github.com/mumoshu/variant.git", "github.com/mumoshu/variant"},
+		{normalizeGitSource, "git://github.com/mumoshu/variant.git", "github.com/mumoshu/variant"},
+		{normalizeGitSource, "git@github.com:mumoshu/variant.git", "github.com/mumoshu/variant"},
+
+		{normalizeDockerHubSource, "mumoshu/variant", "mumoshu/variant"},
+		{normalizeDockerHubSource, "https://hub.docker.com/r/mumoshu/variant", "mumoshu/variant"},
+		{normalizeDockerHubSource, "https://hub.docker.com/_/nginx/", "library/nginx"},
+		{normalizeDockerHubSource, "docker.io/mumoshu/variant", "mumoshu/variant"},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.normalize(tc.input); got != tc.expected {
+			t.Errorf("unexpected normalized source for %q: expected=%q, got=%q", tc.input, tc.expected, got)
+		}
+	}
+}
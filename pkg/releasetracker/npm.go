@@ -0,0 +1,32 @@
+package releasetracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+const DefaultNpmRegistry = "https://registry.npmjs.org"
+
+// newNpmPackageProvider reads the versions of the package out of the keys of `versions` in the packument,
+// which is the map of every published version to its metadata.
+func newNpmPackageProvider(spec NpmPackage, r *Tracker) *httpJsonPathProvider {
+	registry := spec.Registry
+	if registry == "" {
+		registry = DefaultNpmRegistry
+	}
+
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(registry, "/"), escapeNpmPackage(spec.Package))
+
+	return &httpJsonPathProvider{
+		url:      url,
+		jsonpath: "$.versions",
+		headers:  spec.Headers,
+		runtime:  r,
+	}
+}
+
+// escapeNpmPackage escapes the slash in the scoped package name, like `@types/node` to `@types%2fnode`,
+// as the registry expects the name to be a single path segment
+func escapeNpmPackage(pkg string) string {
+	return strings.Replace(pkg, "/", "%2f", 1)
+}
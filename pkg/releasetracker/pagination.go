@@ -0,0 +1,72 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/variantdev/mod/pkg/tmpl"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxPages is the max number of pages fetched from a paginated API, so that a misbehaving API can't make
+// the provider fetch pages forever
+const DefaultMaxPages = 100
+
+// nextCursorPageURL returns the URL of the page pointed by the cursor
+func nextCursorPageURL(firstPageURL, cursor string, c CursorPagination) (string, error) {
+	if c.Template != "" {
+		data := map[string]interface{}{
+			"URL":    firstPageURL,
+			"Cursor": url.QueryEscape(cursor),
+		}
+
+		u, err := tmpl.Render("cursor.template", c.Template, data)
+		if err != nil {
+			return "", fmt.Errorf("rendering the url of the next page: %w", err)
+		}
+
+		return u, nil
+	}
+
+	if c.Param == "" {
+		return "", fmt.Errorf("either param or template is required to request the next page with the cursor")
+	}
+
+	return setQueryParam(firstPageURL, c.Param, cursor)
+}
+
+// setQueryParam returns the URL with the query parameter set to the value, replacing the existing one if any
+func setQueryParam(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// nextLink returns the URL of the `rel="next"` link in the Link header, like
+// `<https://api.github.com/repositories/1/releases?page=2>; rel="next", <https://api.github.com/repositories/1/releases?page=5>; rel="last"`.
+// It returns an empty string when there is no next link.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "rel" && strings.Trim(kv[1], `"`) == "next" {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+
+	return ""
+}
@@ -35,6 +35,19 @@ func TestParse(t *testing.T) {
 				{Semver: semver.MustParse("1.3"), Version: "1.3"},
 			},
 		},
+		{
+			// Loose versions that lack the minor or the patch are padded with zeros by semver.NewVersion
+			[]string{
+				"v1.4",
+				"v1",
+				"2",
+			},
+			[]*Release{
+				{Semver: semver.MustParse("1.0.0"), Version: "1"},
+				{Semver: semver.MustParse("1.4.0"), Version: "1.4"},
+				{Semver: semver.MustParse("2.0.0"), Version: "2"},
+			},
+		},
 	}
 
 	for i := range testcases {
@@ -56,3 +69,23 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestRelease_CoreVersion(t *testing.T) {
+	tr := &Tracker{
+		Logger: klogr.New(),
+	}
+
+	rs, err := tr.versionsToReleases([]string{"v1.2.0-rc.1+build", "1.3", "2.3.4.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cores []string
+	for _, r := range rs {
+		cores = append(cores, r.CoreVersion())
+	}
+
+	if d := cmp.Diff([]string{"1.2.0", "1.3.0", "2.3.4"}, cores); d != "" {
+		t.Errorf("%s", d)
+	}
+}
@@ -0,0 +1,45 @@
+package releasetracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WritePrometheusTextfile writes the latest release matching the constraint to `path` as a metric in the Prometheus
+// text exposition format, like:
+//
+//	mod_latest_version_info{provider="githubReleases",version="1.2.3"} 1
+//
+// so that it can be scraped via the textfile collector of node_exporter.
+// The file is written to a temporary file next to `path` and then renamed, so that the collector never reads a partial file.
+func (p *Tracker) WritePrometheusTextfile(path, constraint string) error {
+	kind, _, err := p.resolveProvider(p.Spec.VersionsFrom)
+	if err != nil {
+		return err
+	}
+
+	latest, err := p.Latest(constraint)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+
+	buf.WriteString("# HELP mod_latest_version_info Latest version of the releases tracked by mod.\n")
+	buf.WriteString("# TYPE mod_latest_version_info gauge\n")
+	fmt.Fprintf(&buf, "mod_latest_version_info{provider=\"%s\",version=\"%s\"} 1\n", escapePrometheusLabelValue(kind), escapePrometheusLabelValue(latest.Version))
+
+	tmp := path + ".tmp"
+
+	if err := p.fs.WriteFile(tmp, []byte(buf.String()), 0644); err != nil {
+		return err
+	}
+
+	return p.fs.Rename(tmp, path)
+}
+
+var prometheusLabelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapePrometheusLabelValue(v string) string {
+	return prometheusLabelValueReplacer.Replace(v)
+}
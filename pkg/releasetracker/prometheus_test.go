@@ -0,0 +1,41 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/twpayne/go-vfs/vfst"
+	"testing"
+)
+
+func TestTracker_WritePrometheusTextfile(t *testing.T) {
+	fs, clean, err := vfst.NewTestFS(map[string]interface{}{
+		"/textfile": &vfst.Dir{Perm: 0755},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	tracker := newFakeExecTracker(t, Spec{}, "v1.2.3\nv1.3.0-rc.1\nv1.1.0\n", FS(fs), WD("/work"))
+
+	if err := tracker.WritePrometheusTextfile("/textfile/mod.prom", "< 1.3.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fs.ReadFile("/textfile/mod.prom")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP mod_latest_version_info Latest version of the releases tracked by mod.
+# TYPE mod_latest_version_info gauge
+mod_latest_version_info{provider="exec",version="1.2.3"} 1
+`
+
+	if d := cmp.Diff(expected, string(got)); d != "" {
+		t.Errorf("%s", d)
+	}
+
+	if _, err := fs.Stat("/textfile/mod.prom.tmp"); err == nil {
+		t.Errorf("the temporary file should have been renamed")
+	}
+}
@@ -0,0 +1,119 @@
+package releasetracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const DefaultPyPIIndex = "https://pypi.org/pypi"
+
+type pypiProvider struct {
+	spec PyPIPackage
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &pypiProvider{}
+
+func newPyPIProvider(spec PyPIPackage, r *Tracker) *pypiProvider {
+	return &pypiProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+// All reads the versions of the package out of the keys of `releases` in `{index}/{package}/json`.
+// Release.Version is the version as published, whereas Release.Semver is parsed from the version coerced by coercePEP440.
+func (p *pypiProvider) All() ([]*Release, error) {
+	url := p.sourceURL()
+
+	res, err := p.runtime.httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var info struct {
+		Releases map[string]json.RawMessage `json:"releases"`
+	}
+
+	if err := json.Unmarshal([]byte(res), &info); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", url, err)
+	}
+
+	originals := map[string]string{}
+
+	var vs []string
+
+	for v := range info.Releases {
+		coerced := coercePEP440(v)
+		originals[strings.TrimPrefix(coerced, "v")] = v
+		vs = append(vs, coerced)
+	}
+
+	rs, err := p.runtime.versionsToReleases(vs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rs {
+		if v, ok := originals[r.Version]; ok {
+			r.Version = v
+		}
+	}
+
+	return rs, nil
+}
+
+func (p *pypiProvider) sourceURL() string {
+	index := p.spec.Index
+	if index == "" {
+		index = DefaultPyPIIndex
+	}
+
+	return fmt.Sprintf("%s/%s/json", strings.TrimSuffix(index, "/"), p.spec.Package)
+}
+
+var pep440Regex = regexp.MustCompile(`(?i)^v?(?:\d+!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|rc|c|alpha|beta|pre|preview)[-_.]?(\d*))?` +
+	`(?:[-_.]?(post|rev|r)[-_.]?(\d*)|-(\d+))?` +
+	`(?:[-_.]?(dev)[-_.]?(\d*))?` +
+	`(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+
+// coercePEP440 converts the PEP 440 version into a semver, like `1.0rc1` to `1.0-rc1` and `1.0.dev2` to `1.0-dev2`.
+// The epoch like `1!` and the local version like `+ubuntu1` are dropped, and post releases like `1.0.post1`
+// become the build metadata, which makes them as new as the release they follow.
+// The string is returned as-is when it isn't a PEP 440 version.
+func coercePEP440(s string) string {
+	m := pep440Regex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return s
+	}
+
+	release, preLabel, preNum, postLabel, postNum, implicitPostNum, devLabel, devNum := m[1], m[2], m[3], m[4], m[5], m[6], m[7], m[8]
+
+	var pre []string
+
+	if preLabel != "" {
+		pre = append(pre, strings.ToLower(preLabel)+preNum)
+	}
+
+	if devLabel != "" {
+		pre = append(pre, "dev"+devNum)
+	}
+
+	v := release
+
+	if len(pre) > 0 {
+		v += "-" + strings.Join(pre, ".")
+	}
+
+	if implicitPostNum != "" {
+		v += "+post" + implicitPostNum
+	} else if postLabel != "" {
+		v += "+post" + postNum
+	}
+
+	return v
+}
@@ -0,0 +1,72 @@
+package releasetracker
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"testing"
+)
+
+func TestCoercePEP440(t *testing.T) {
+	testcases := []struct {
+		in, expected string
+	}{
+		{in: "2.31.0", expected: "2.31.0"},
+		{in: "1.0rc1", expected: "1.0-rc1"},
+		{in: "1.0.0b2", expected: "1.0.0-b2"},
+		{in: "1.0.dev3", expected: "1.0-dev3"},
+		{in: "1.0a1.dev2", expected: "1.0-a1.dev2"},
+		{in: "1.0.post1", expected: "1.0+post1"},
+		{in: "1.0-1", expected: "1.0+post1"},
+		{in: "1!2.0", expected: "2.0"},
+		{in: "2.0+ubuntu1", expected: "2.0"},
+		{in: "not-a-version", expected: "not-a-version"},
+	}
+
+	for _, tc := range testcases {
+		if got := coercePEP440(tc.in); got != tc.expected {
+			t.Errorf("%s: expected=%s, got=%s", tc.in, tc.expected, got)
+		}
+	}
+}
+
+func TestProvider_PyPIPackage(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://pypi.org/pypi/requests/json"}: `{
+  "info": {"name": "requests", "version": "2.31.0"},
+  "releases": {
+    "2.30.0": [],
+    "2.31.0": [],
+    "2.32.0rc1": [],
+    "2.29.0.post1": []
+  }
+}`,
+	}
+
+	tracker, err := New(Spec{VersionsFrom: VersionsFrom{PyPIPackage: PyPIPackage{Package: "requests"}}}, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"2.29.0.post1", "2.30.0", "2.31.0", "2.32.0rc1"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	latest, err := tracker.Latest(">= 2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "2.31.0" {
+		t.Errorf("unexpected latest version: expected=2.31.0, got=%s", latest.Version)
+	}
+}
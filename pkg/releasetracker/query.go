@@ -0,0 +1,335 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Releases returns all the releases in ascending order of versions, like GetReleases.
+// Use Descending to get the newest release first, and Constraint to filter the releases.
+func (p *Tracker) Releases(opts ...ListOption) ([]*Release, error) {
+	o := &listOptions{}
+	for _, opt := range opts {
+		opt.SetListOption(o)
+	}
+
+	all, err := p.getReleases(context.Background(), namesPrerelease(o.constraint))
+	if err != nil {
+		return nil, err
+	}
+
+	rs := all
+
+	if o.constraint != "" {
+		rs, err = matchingReleases(o.constraint, all)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.descending {
+		desc := make([]*Release, 0, len(rs))
+		for i := len(rs) - 1; i >= 0; i-- {
+			desc = append(desc, rs[i])
+		}
+		rs = desc
+	}
+
+	return rs, nil
+}
+
+// PromotedStable reports whether the prerelease, like `1.2.0-rc.1`, has been promoted to the stable release of the same
+// version, `1.2.0`, and returns the stable release if so. The "v" prefix is accepted as in `v1.2.0-rc.1`.
+func (p *Tracker) PromotedStable(prerelease string) (*Release, bool, error) {
+	pre, err := p.parseVersion(prerelease)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing version %q: %v", prerelease, err)
+	}
+
+	if pre.Prerelease() == "" {
+		return nil, false, fmt.Errorf("%q is not a prerelease", prerelease)
+	}
+
+	all, err := p.GetReleases()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, r := range all {
+		v := r.Semver
+		if v.Prerelease() == "" && sameCore(v, pre) {
+			return r, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// LatestStable returns the newest stable release, which is the one without the prerelease part like `-rc.1`.
+// The build metadata doesn't make a release a prerelease, so `1.2.0+build.1` is stable.
+//
+// Unlike Latest(""), which matches DefaultConstraint `> 0.0.0-0` and so returns `1.3.0-rc.1` over `1.2.0`, LatestStable never returns
+// a prerelease. ErrNoMatchingRelease is returned when there are prereleases only.
+func (p *Tracker) LatestStable() (*Release, error) {
+	// A constraint without a prerelease never matches prereleases
+	return p.latestStableMatching(">= 0.0.0")
+}
+
+// LatestPatch returns the newest stable release of the minor version, like `1.4.7` for `LatestPatch(1, 4)`,
+// without the caveats of the tilde and caret constraints.
+func (p *Tracker) LatestPatch(major, minor int) (*Release, error) {
+	return p.latestStableMatching(fmt.Sprintf(">= %d.%d.0, < %d.%d.0", major, minor, major, minor+1))
+}
+
+// LatestMinor returns the newest stable release of the major version, like `1.9.2` for `LatestMinor(1)`.
+func (p *Tracker) LatestMinor(major int) (*Release, error) {
+	return p.latestStableMatching(fmt.Sprintf(">= %d.0.0, < %d.0.0", major, major+1))
+}
+
+// latestStableMatching is Latest that never returns a prerelease, as long as the constraint doesn't name one
+func (p *Tracker) latestStableMatching(constraint string) (*Release, error) {
+	all, err := p.getReleases(context.Background(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.latestOf(constraint, all)
+}
+
+// LatestBefore returns the latest release satisfying the constraint among the ones published before `t`,
+// for reproducing a build as of the time. The releases lacking the publish date are excluded.
+func (p *Tracker) LatestBefore(constraint string, t time.Time) (*Release, error) {
+	all, err := p.getReleases(context.Background(), namesPrerelease(constraint))
+	if err != nil {
+		return nil, err
+	}
+
+	var published []*Release
+
+	for _, r := range all {
+		if !r.PublishedAt.IsZero() && r.PublishedAt.Before(t) {
+			published = append(published, r)
+		}
+	}
+
+	if len(published) == 0 {
+		return nil, fmt.Errorf("no release published before %s found", t.Format(time.RFC3339))
+	}
+
+	return p.latestOf(constraint, published)
+}
+
+// ReleasedWithin returns the releases published within `window` after the base release, in ascending order of versions.
+// The base release itself and the releases lacking the publish date are excluded.
+func (p *Tracker) ReleasedWithin(baseVersion string, window time.Duration) ([]*Release, error) {
+	baseSemver, err := p.parseVersion(baseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing version %q: %v", baseVersion, err)
+	}
+
+	all, err := p.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	var base *Release
+
+	for _, r := range all {
+		if r.Semver.Equal(baseSemver) {
+			base = r
+			break
+		}
+	}
+
+	if base == nil {
+		return nil, fmt.Errorf("base release %q not found", baseVersion)
+	}
+
+	if base.PublishedAt.IsZero() {
+		return nil, fmt.Errorf("base release %q lacks the publish date", baseVersion)
+	}
+
+	until := base.PublishedAt.Add(window)
+
+	var rs []*Release
+
+	for _, r := range all {
+		if r == base || r.PublishedAt.IsZero() {
+			continue
+		}
+
+		if r.PublishedAt.After(base.PublishedAt) && !r.PublishedAt.After(until) {
+			rs = append(rs, r)
+		}
+	}
+
+	return rs, nil
+}
+
+// IsLatest reports whether the version, like `v1.2.0` or `1.2.0`, is the latest release matching the constraint.
+// It returns false without an error when the version is behind the latest, and an error when the version can't be parsed.
+func (p *Tracker) IsLatest(version, constraint string) (bool, error) {
+	v, err := p.parseVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("parsing version %q: %v", version, err)
+	}
+
+	latest, err := p.Latest(constraint)
+	if err != nil {
+		return false, err
+	}
+
+	return latest.Semver.Equal(v), nil
+}
+
+// Next returns the oldest release that is newer than the current version and satisfies the constraint, for upgrading
+// one release at a time rather than jumping to the latest. The error matches ErrAlreadyLatest when there is none.
+func (p *Tracker) Next(current, constraint string) (*Release, error) {
+	cur, err := p.parseVersion(current)
+	if err != nil {
+		return nil, fmt.Errorf("parsing version %q: %v", current, err)
+	}
+
+	all, err := p.getReleases(context.Background(), namesPrerelease(constraint))
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := matchingReleases(constraint, all)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *Release
+
+	// The releases are in the order of the source when Spec.PreserveOrder is set, so the oldest one is looked for
+	for _, r := range rs {
+		if p.less(cur, r.Semver) && (next == nil || p.less(r.Semver, next.Semver)) {
+			next = r
+		}
+	}
+
+	if next != nil {
+		return next, nil
+	}
+
+	return nil, fmt.Errorf("no release newer than %q matching %q found: %w", current, constraint, ErrAlreadyLatest)
+}
+
+// Has reports whether the version is still published by the versions source, like before building with a pinned version.
+// The version matches a release of the same semver, so that `1.2.0` matches `v1.2.0`, or of the same version string.
+// Prereleases are looked up even when Spec.ExcludePrereleases is set.
+func (p *Tracker) Has(version string) (bool, error) {
+	all, err := p.getReleases(context.Background(), true)
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range all {
+		if r.Version == version {
+			return true, nil
+		}
+	}
+
+	v, err := p.parseVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("parsing version %q: %v", version, err)
+	}
+
+	for _, r := range all {
+		if r.Semver.Equal(v) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+const (
+	BumpMajor      = "major"
+	BumpMinor      = "minor"
+	BumpPatch      = "patch"
+	BumpPrerelease = "prerelease"
+	BumpNone       = "none"
+)
+
+// BumpType returns which part of the version the latest release matching the constraint bumped over the baseline,
+// i.e. one of `major`, `minor`, `patch`, `prerelease`, or `none`, along with the latest release.
+//
+// `prerelease` means the latest has the same core version as the baseline but a higher prerelease, like
+// `1.2.0-rc.2` over `1.2.0-rc.1`, or is the stable release of the baseline prerelease.
+// `none` means the latest is not newer than the baseline.
+func (p *Tracker) BumpType(baseline, constraint string) (string, *Release, error) {
+	base, err := p.parseVersion(baseline)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing version %q: %v", baseline, err)
+	}
+
+	latest, err := p.Latest(constraint)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v := latest.Semver
+
+	switch {
+	case !base.LessThan(v):
+		return BumpNone, latest, nil
+	case v.Major() != base.Major():
+		return BumpMajor, latest, nil
+	case v.Minor() != base.Minor():
+		return BumpMinor, latest, nil
+	case v.Patch() != base.Patch():
+		return BumpPatch, latest, nil
+	}
+
+	return BumpPrerelease, latest, nil
+}
+
+// ByChannel groups the releases into channels by the prerelease identifier, in ascending order of versions.
+//
+// Stable releases are grouped into `stable`. Prereleases are grouped by the leading letters of the prerelease,
+// so that `1.2.0-rc.1` and `1.2.0-rc1` are in `rc`, `1.2.0-beta.2` in `beta`, and `1.2.0-alpha` in `alpha`.
+// Prereleases that don't start with a letter, like `1.2.3-123` for the non-semver version `1.2.3.123`, are in `prerelease`.
+func (p *Tracker) ByChannel() (map[string][]*Release, error) {
+	all, err := p.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	channels := map[string][]*Release{}
+
+	for _, r := range all {
+		ch := releaseChannel(r.Semver.Prerelease())
+
+		channels[ch] = append(channels[ch], r)
+	}
+
+	for _, rs := range channels {
+		p.sortReleases(rs)
+	}
+
+	return channels, nil
+}
+
+func releaseChannel(prerelease string) string {
+	if prerelease == "" {
+		return "stable"
+	}
+
+	i := strings.IndexFunc(prerelease, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if i < 0 {
+		i = len(prerelease)
+	}
+
+	if i == 0 {
+		return "prerelease"
+	}
+
+	return strings.ToLower(prerelease[:i])
+}
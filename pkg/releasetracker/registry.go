@@ -0,0 +1,110 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/heroku/docker-registry-client/registry"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// registryTags lists the tags of the repository via the Docker Registry HTTP API V2, following the pagination.
+// The registry client handles the Bearer token handshake initiated by the `WWW-Authenticate` challenge,
+// which registries like GHCR require even for anonymous pulls.
+func (p *Tracker) registryTags(registryURL, repository, username, password string) ([]string, error) {
+	transport, timeout := http.DefaultTransport, p.httpTimeout
+	if c := p.httpClient; c != nil {
+		if c.Transport != nil {
+			transport = c.Transport
+		}
+		timeout = c.Timeout
+	}
+
+	ua := p.userAgent
+	if ua == "" {
+		ua = vhttpget.DefaultUserAgent
+	}
+
+	transport = &userAgentTransport{ua: ua, next: transport}
+
+	if p.urlRewriter != nil {
+		transport = &urlRewriterTransport{rewrite: p.rewriteURL, next: transport}
+	}
+
+	reg := &registry.Registry{
+		URL: registryURL,
+		Client: &http.Client{
+			Transport: registry.WrapTransport(transport, registryURL, username, password),
+			Timeout:   timeout,
+		},
+		Logf: registry.Quiet,
+	}
+
+	return reg.Tags(repository)
+}
+
+// userAgentTransport sets the `User-Agent` header of the requests lacking it
+type userAgentTransport struct {
+	ua   string
+	next http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request
+	r := req.Clone(req.Context())
+	r.Header.Set("User-Agent", t.ua)
+
+	return t.next.RoundTrip(r)
+}
+
+// urlRewriterTransport rewrites the URL of each request, including the ones to the token endpoint of the registry.
+// It is wrapped by the registry's auth transport, so that credentials are chosen by the URL before rewriting
+type urlRewriterTransport struct {
+	rewrite func(string) string
+	next    http.RoundTripper
+}
+
+func (t *urlRewriterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := t.rewrite(req.URL.String())
+	if rewritten == req.URL.String() {
+		return t.next.RoundTrip(req)
+	}
+
+	u, err := url.Parse(rewritten)
+	if err != nil {
+		return nil, fmt.Errorf("parsing rewritten url %q: %w", rewritten, err)
+	}
+
+	// A RoundTripper must not modify the request
+	r := req.Clone(req.Context())
+	r.URL = u
+	r.Host = ""
+
+	return t.next.RoundTrip(r)
+}
+
+// registryRepository returns the base URL of the registry and the repository.
+// The registry can be given with the `oci://` scheme and the namespace of the repository, as in
+// `oci://registry.example.com/charts`, which is the form `helm pull` accepts.
+func registryRepository(reg, repository string) (string, string) {
+	reg = strings.TrimSuffix(strings.TrimPrefix(reg, "oci://"), "/")
+
+	if !strings.Contains(reg, "://") {
+		reg = "https://" + reg
+	}
+
+	scheme := reg[:strings.Index(reg, "://")+3]
+	hostAndPath := strings.SplitN(strings.TrimPrefix(reg, scheme), "/", 2)
+
+	repo := strings.TrimPrefix(repository, "/")
+	if len(hostAndPath) == 2 && hostAndPath[1] != "" {
+		repo = hostAndPath[1] + "/" + repo
+	}
+
+	return scheme + hostAndPath[0], repo
+}
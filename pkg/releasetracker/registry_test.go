@@ -0,0 +1,76 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryRepository(t *testing.T) {
+	testcases := []struct {
+		registry, chart string
+		url, repo       string
+	}{
+		{"oci://registry.example.com/charts", "mychart", "https://registry.example.com", "charts/mychart"},
+		{"registry.example.com", "mychart", "https://registry.example.com", "mychart"},
+		{"http://localhost:5000/", "org/mychart", "http://localhost:5000", "org/mychart"},
+	}
+
+	for _, tc := range testcases {
+		url, repo := registryRepository(tc.registry, tc.chart)
+		if url != tc.url || repo != tc.repo {
+			t.Errorf("unexpected repository for %s %s: expected=%s %s, got=%s %s", tc.registry, tc.chart, tc.url, tc.repo, url, repo)
+		}
+	}
+}
+
+func TestRegistryTags_URLRewriter(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			fmt.Fprint(w, `{"token": "anonymous-token"}`)
+		case "/v2/myorg/myapp/tags/list":
+			if r.Header.Get("Authorization") != "Bearer anonymous-token" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="https://registry.example.com/token",service="registry.example.com"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"name": "myorg/myapp", "tags": ["1.0.0", "1.1.0"]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	spec := Spec{VersionsFrom: VersionsFrom{ContainerImageTags: ContainerImageTags{
+		Registry:   "https://registry.example.com",
+		Repository: "myorg/myapp",
+	}}}
+
+	tracker, err := New(spec, URLRewriter(func(u string) string {
+		return strings.Replace(u, "https://registry.example.com", srv.URL, 1)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0"}, got); d != "" {
+		t.Errorf("%s", d)
+	}
+}
@@ -0,0 +1,191 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetryOnEmptyAttempts = 3
+	DefaultRetryOnEmptyBackoff  = time.Second
+)
+
+// FetchAttempt is the outcome of fetching releases from a single versions source.
+type FetchAttempt struct {
+	// Provider is the kind of the source, like `githubReleases`
+	Provider string
+
+	// Source is where the releases are fetched from, which is the URL of the source, or the command line for exec
+	Source string
+
+	// Releases is the number of releases returned by the source
+	Releases int
+
+	Duration time.Duration
+
+	// Err is the error returned by the source. Nil when the attempt succeeded.
+	Err error
+}
+
+// FetchReport summarizes the versions sources attempted while fetching releases.
+type FetchReport struct {
+	Attempts []FetchAttempt
+}
+
+// LastFetchReport returns the report of the latest GetReleases call.
+func (p *Tracker) LastFetchReport() FetchReport {
+	p.reportMu.Lock()
+	defer p.reportMu.Unlock()
+
+	return p.lastFetchReport
+}
+
+func (p *Tracker) setLastFetchReport(r *FetchReport) {
+	p.reportMu.Lock()
+	defer p.reportMu.Unlock()
+
+	p.lastFetchReport = *r
+}
+
+// fetch fetches all the releases from the provider, recording the attempt into the report
+func (p *Tracker) fetch(ctx context.Context, report *FetchReport, kind string, vf VersionsFrom, pp ReleaseProvider) ([]*Release, error) {
+	start := time.Now()
+
+	all, err := allContext(withSourceKey(ctx, vf), pp)
+
+	source := p.fetchSource(vf)
+
+	attempt := FetchAttempt{
+		Provider: kind,
+		Source:   source,
+		Releases: len(all),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+
+	report.Attempts = append(report.Attempts, attempt)
+
+	if err != nil {
+		p.Logger.V(1).Info("fetching releases failed", "provider", kind, "source", source, "duration", attempt.Duration, "error", err.Error())
+	} else {
+		p.Logger.V(1).Info("fetched releases", "provider", kind, "source", source, "count", attempt.Releases, "duration", attempt.Duration)
+	}
+
+	return all, err
+}
+
+// fetchSource returns where the releases are fetched from the versions source for logging,
+// which is the URL of the source, or the command line for exec
+func (p *Tracker) fetchSource(vf VersionsFrom) string {
+	if u := p.sourceURLOf(vf); u != "" {
+		return u
+	}
+
+	if e := vf.Exec; e.Command != "" {
+		return strings.Join(append([]string{e.Command}, e.Args...), " ")
+	}
+
+	return ""
+}
+
+// fetchFallingBack fetches releases from the versions source, trying the fallbacks in order while the source fails
+// or returns no releases. It returns the kind of the source that the releases are fetched from.
+// The error of the last source is returned when all of them failed.
+func (p *Tracker) fetchFallingBack(ctx context.Context, report *FetchReport) (string, []*Release, error) {
+	sources := append([]VersionsFrom{p.Spec.VersionsFrom}, p.Spec.VersionsFrom.Fallbacks...)
+
+	for i, vf := range sources {
+		kind, pp, err := p.resolveProvider(vf)
+		if err != nil {
+			return "", nil, err
+		}
+
+		all, err := p.fetchRetryingOnEmpty(ctx, report, kind, vf, pp)
+
+		last := i == len(sources)-1
+		if last || ctx.Err() != nil || (err == nil && len(all) > 0) {
+			return kind, all, err
+		}
+
+		if err != nil {
+			p.Logger.Info("falling back to the next versions source", "provider", kind, "error", err.Error())
+		} else {
+			p.Logger.Info("falling back to the next versions source", "provider", kind, "count", 0)
+		}
+	}
+
+	return "", nil, fmt.Errorf("no versions provider specified")
+}
+
+// fetchRetryingOnEmpty is fetch that re-fetches releases while the provider returns none, when RetryOnEmpty is enabled
+func (p *Tracker) fetchRetryingOnEmpty(ctx context.Context, report *FetchReport, kind string, vf VersionsFrom, pp ReleaseProvider) ([]*Release, error) {
+	all, err := p.fetch(ctx, report, kind, vf, pp)
+	if err != nil || !p.Spec.RetryOnEmpty {
+		return all, err
+	}
+
+	attempts := p.Spec.RetryOnEmptyAttempts
+	if attempts <= 0 {
+		attempts = DefaultRetryOnEmptyAttempts
+	}
+
+	wait := p.Spec.RetryOnEmptyBackoff
+	if wait <= 0 {
+		wait = DefaultRetryOnEmptyBackoff
+	}
+
+	for i := 1; len(all) == 0 && i < attempts; i++ {
+		p.Logger.V(1).Info("provider returned no releases. retrying", "provider", kind, "attempt", i+1, "wait", wait)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("retrying on empty releases: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+
+		all, err = p.fetch(ctx, report, kind, vf, pp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return all, nil
+}
+
+// allContext fetches all the releases from the provider until the context is done
+func allContext(ctx context.Context, pp ReleaseProvider) ([]*Release, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if cp, ok := pp.(ContextReleaseProvider); ok {
+		return cp.AllContext(ctx)
+	}
+
+	if ctx.Done() == nil {
+		return pp.All()
+	}
+
+	type result struct {
+		releases []*Release
+		err      error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		rs, err := pp.All()
+		ch <- result{releases: rs, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("fetching releases: %w", ctx.Err())
+	case r := <-ch:
+		return r.releases, r.err
+	}
+}
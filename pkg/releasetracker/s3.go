@@ -0,0 +1,145 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"regexp"
+	"strings"
+)
+
+// S3Lister lists the keys of the objects in an S3 bucket.
+type S3Lister interface {
+	// ListKeys returns the keys of all the objects in the bucket whose keys start with the prefix.
+	ListKeys(bucket, prefix string) ([]string, error)
+}
+
+type s3Provider struct {
+	spec   S3Bucket
+	lister S3Lister
+
+	runtime *Tracker
+}
+
+var _ ReleaseProvider = &s3Provider{}
+
+func newS3Provider(spec S3Bucket, r *Tracker) *s3Provider {
+	lister := r.s3Lister
+	if lister == nil {
+		lister = &awsS3Lister{region: spec.Region}
+	}
+
+	return &s3Provider{
+		spec:    spec,
+		lister:  lister,
+		runtime: r,
+	}
+}
+
+func (p *s3Provider) All() ([]*Release, error) {
+	var pattern *regexp.Regexp
+
+	if p.spec.VersionPattern != "" {
+		var err error
+
+		pattern, err = regexp.Compile(p.spec.VersionPattern)
+		if err != nil {
+			return nil, fmt.Errorf("compiling versionPattern %q: %v", p.spec.VersionPattern, err)
+		}
+	}
+
+	keys, err := p.lister.ListKeys(p.spec.Bucket, p.spec.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects in s3://%s/%s: %v", p.spec.Bucket, p.spec.Prefix, err)
+	}
+
+	seen := map[string]bool{}
+
+	var vs []string
+
+	for _, key := range keys {
+		v, ok := p.versionInKey(key, pattern)
+		if !ok || seen[v] {
+			continue
+		}
+
+		seen[v] = true
+
+		vs = append(vs, v)
+	}
+
+	return p.runtime.versionsToReleases(vs)
+}
+
+// versionInKey extracts the version from the key, like `1.2.3` from `releases/1.2.3/app.tar.gz`
+func (p *s3Provider) versionInKey(key string, pattern *regexp.Regexp) (string, bool) {
+	if pattern != nil {
+		m := pattern.FindStringSubmatch(key)
+		if m == nil {
+			return "", false
+		}
+
+		if len(m) > 1 {
+			return m[1], true
+		}
+
+		return m[0], true
+	}
+
+	segments := strings.Split(strings.TrimPrefix(strings.TrimPrefix(key, p.spec.Prefix), "/"), "/")
+	if p.spec.Segment < 0 || p.spec.Segment >= len(segments) {
+		return "", false
+	}
+
+	if v := segments[p.spec.Segment]; v != "" {
+		return v, true
+	}
+
+	return "", false
+}
+
+func (p *s3Provider) sourceURL() string {
+	return fmt.Sprintf("s3://%s/%s", p.spec.Bucket, p.spec.Prefix)
+}
+
+// awsS3Lister lists the objects via the AWS SDK, with the credentials from the default chain
+type awsS3Lister struct {
+	region string
+}
+
+func (l *awsS3Lister) ListKeys(bucket, prefix string) ([]string, error) {
+	conf := aws.NewConfig()
+	if l.region != "" {
+		conf = conf.WithRegion(l.region)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *conf,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	var keys []string
+
+	err = s3.New(sess).ListObjectsV2Pages(input, func(out *s3.ListObjectsV2Output, last bool) bool {
+		for _, o := range out.Contents {
+			keys = append(keys, aws.StringValue(o.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
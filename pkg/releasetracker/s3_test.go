@@ -0,0 +1,89 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type stubS3 struct {
+	bucket string
+	prefix string
+	keys   []string
+}
+
+func (s *stubS3) ListKeys(bucket, prefix string) ([]string, error) {
+	if bucket != s.bucket || prefix != s.prefix {
+		return nil, fmt.Errorf("unexpected bucket: bucket=%q, prefix=%q", bucket, prefix)
+	}
+	return s.keys, nil
+}
+
+func TestProvider_S3(t *testing.T) {
+	keys := []string{
+		"releases/1.0.0/app.tar.gz",
+		"releases/1.0.0/app.zip",
+		"releases/1.2.0/app.tar.gz",
+		"releases/1.1.0/app.tar.gz",
+		"releases/latest/app.tar.gz",
+	}
+
+	testcases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "segment",
+			input: `
+bucket: artifacts
+prefix: releases/
+`,
+			expected: []string{"1.0.0", "1.1.0", "1.2.0"},
+		},
+		{
+			name: "versionPattern",
+			input: `
+bucket: artifacts
+prefix: releases/
+versionPattern: '^releases/([^/]+)/app\.tar\.gz$'
+`,
+			expected: []string{"1.0.0", "1.1.0", "1.2.0"},
+		},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var spec S3Bucket
+			if err := yaml.Unmarshal([]byte(tc.input), &spec); err != nil {
+				t.Fatal(err)
+			}
+
+			tracker, err := New(Spec{VersionsFrom: VersionsFrom{S3: spec}}, S3Client(&stubS3{bucket: "artifacts", prefix: "releases/", keys: keys}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rs, err := tracker.GetReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var vs []string
+			for _, r := range rs {
+				vs = append(vs, r.Version)
+			}
+
+			if d := cmp.Diff(tc.expected, vs); d != "" {
+				t.Errorf("unexpected versions: %s", d)
+			}
+
+			if got := tracker.SourceURL(); got != "s3://artifacts/releases/" {
+				t.Errorf("unexpected source url: %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package releasetracker
+
+import (
+	"context"
+	"fmt"
+)
+
+type scoopProvider struct {
+	spec Scoop
+
+	runtime *Tracker
+}
+
+var _ ContextReleaseProvider = &scoopProvider{}
+
+func newScoopProvider(spec Scoop, r *Tracker) *scoopProvider {
+	return &scoopProvider{
+		spec:    spec,
+		runtime: r,
+	}
+}
+
+func (p *scoopProvider) All() ([]*Release, error) {
+	return p.AllContext(context.Background())
+}
+
+func (p *scoopProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	if p.spec.App == "" {
+		return nil, fmt.Errorf("scoop: app must be specified")
+	}
+
+	// Buckets store manifests under `bucket/` nowadays, whereas older buckets store them in the root directory
+	paths := []string{"bucket/" + p.spec.App + ".json", p.spec.App + ".json"}
+
+	var errs []error
+
+	for _, path := range paths {
+		manifest, err := p.runtime.readGitFile(ctx, GitFile{Repo: p.spec.Bucket, Ref: p.spec.Ref, Path: path})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		vs, err := p.runtime.versionsFromDocuments([]byte(manifest), "$.version")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		return p.runtime.versionsToReleases(vs)
+	}
+
+	return nil, fmt.Errorf("scoop: manifest of %s not found in %s: %v", p.spec.App, p.spec.Bucket, errs)
+}
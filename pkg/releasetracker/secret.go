@@ -0,0 +1,61 @@
+package releasetracker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SecretResolver resolves a reference to a secret, like `vault:secret/github#token`, into the secret value.
+//
+// Tokens and passwords in the spec are resolved via the tracker's resolver, set via the Secrets option,
+// so that the config never contains the secret values themselves.
+type SecretResolver interface {
+	Resolve(ref string) (string, error)
+}
+
+// SecretResolverFunc adapts an ordinary function to SecretResolver
+type SecretResolverFunc func(ref string) (string, error)
+
+func (f SecretResolverFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// resolveSecret resolves the secret reference.
+//
+// `env:NAME` is replaced with the value of the envvar `NAME`, and `file:PATH` with the content of the file with
+// the trailing newline trimmed. Any other reference is passed to the resolver set via the Secrets option.
+// Without the resolver, the reference is used as the secret value as-is.
+func (p *Tracker) resolveSecret(ref string) (string, error) {
+	switch {
+	case ref == "":
+		return "", nil
+	case strings.HasPrefix(ref, "env:"):
+		name := strings.TrimPrefix(ref, "env:")
+
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("resolving secret %q: envvar %s is not set", ref, name)
+		}
+
+		return v, nil
+	case strings.HasPrefix(ref, "file:"):
+		path := strings.TrimPrefix(ref, "file:")
+
+		bs, err := p.fs.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("resolving secret %q: %w", ref, err)
+		}
+
+		return strings.TrimRight(string(bs), "\r\n"), nil
+	case p.secretResolver != nil:
+		v, err := p.secretResolver.Resolve(ref)
+		if err != nil {
+			return "", fmt.Errorf("resolving secret %q: %w", ref, err)
+		}
+
+		return v, nil
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,82 @@
+package releasetracker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/twpayne/go-vfs/vfst"
+	"github.com/variantdev/mod/pkg/vhttpget"
+	"testing"
+)
+
+func TestTracker_Secrets(t *testing.T) {
+	fs, clean, err := vfst.NewTestFS(map[string]interface{}{
+		"/secrets/consul-token": "file-token\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	defer setenv(t, "TEST_CONSUL_TOKEN", "env-token")()
+
+	vault := SecretResolverFunc(func(ref string) (string, error) {
+		if ref == "vault:secret/consul#token" {
+			return "vault-token", nil
+		}
+		return ref, nil
+	})
+
+	testcases := []struct {
+		token    string
+		expected string
+	}{
+		{token: "vault:secret/consul#token", expected: "vault-token"},
+		{token: "env:TEST_CONSUL_TOKEN", expected: "env-token"},
+		{token: "file:/secrets/consul-token", expected: "file-token"},
+		{token: "plain-token", expected: "plain-token"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.token, func(t *testing.T) {
+			value := base64.StdEncoding.EncodeToString([]byte("1.2.0"))
+
+			gets := map[vhttpget.TestGetInput]string{
+				vhttpget.TestGetInput{URL: "http://127.0.0.1:8500/v1/kv/releases/myapp", Headers: "X-Consul-Token: " + tc.expected}: `[{"Key": "releases/myapp", "Value": "` + value + `"}]`,
+			}
+
+			conf := Spec{VersionsFrom: VersionsFrom{ConsulKV: ConsulKV{Key: "releases/myapp", Token: tc.token}}}
+
+			tracker, err := New(conf, FS(fs), HttpGetter(vhttpget.NewTester(gets)), Secrets(vault))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest.Version != "1.2.0" {
+				t.Errorf("unexpected version: expected=1.2.0, got=%v", latest.Version)
+			}
+		})
+	}
+}
+
+func TestTracker_Secrets_ResolverError(t *testing.T) {
+	vault := SecretResolverFunc(func(ref string) (string, error) {
+		return "", fmt.Errorf("permission denied")
+	})
+
+	conf := Spec{VersionsFrom: VersionsFrom{ConsulKV: ConsulKV{Key: "releases/myapp", Token: "vault:secret/consul#token"}}}
+
+	tracker, err := New(conf, HttpGetter(vhttpget.NewTester(map[vhttpget.TestGetInput]string{})), Secrets(vault))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tracker.Latest("")
+	if err == nil || err.Error() != `resolving secret "vault:secret/consul#token": permission denied` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
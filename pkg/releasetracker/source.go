@@ -0,0 +1,35 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/variantdev/mod/pkg/tmpl"
+)
+
+// LatestSource returns the go-getter URL of the source of the latest release matching the constraint,
+// rendered from `sourceTemplate`. The URL can be passed to `depresolver.Resolver` to download the source.
+func (p *Tracker) LatestSource(constraint string) (string, error) {
+	if p.Spec.SourceTemplate == "" {
+		return "", fmt.Errorf("sourceTemplate is required to obtain the source of a release")
+	}
+
+	latest, err := p.Latest(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	return p.renderSource(latest)
+}
+
+func (p *Tracker) renderSource(r *Release) (string, error) {
+	data := map[string]interface{}{
+		"Version": r.Version,
+		"Semver":  r.Semver.String(),
+	}
+
+	src, err := tmpl.Render("sourceTemplate", p.Spec.SourceTemplate, data)
+	if err != nil {
+		return "", fmt.Errorf("rendering sourceTemplate for version %s: %w", r.Version, err)
+	}
+
+	return src, nil
+}
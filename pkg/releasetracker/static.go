@@ -0,0 +1,43 @@
+package releasetracker
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"strings"
+)
+
+// StaticProvider is a ReleaseProvider that returns the releases of the fixed versions, like `v1.2.0` or `1.2.0`,
+// for testing code that embeds the tracker against deterministic releases. Use it via the Provider option.
+type StaticProvider struct {
+	Versions []string
+}
+
+// NewStaticProvider returns the StaticProvider for the versions
+func NewStaticProvider(versions ...string) *StaticProvider {
+	return &StaticProvider{Versions: versions}
+}
+
+// All returns the releases of the versions in the given order, which are sorted by the tracker.
+// It fails when any of the versions can't be parsed.
+func (p *StaticProvider) All() ([]*Release, error) {
+	rs := make([]*Release, 0, len(p.Versions))
+
+	for i, s := range p.Versions {
+		v, err := semver.NewVersion(nonSemverWorkaround(s))
+		if err != nil {
+			return nil, fmt.Errorf("parsing version: index %d: %q: %v", i, s, err)
+		}
+
+		rs = append(rs, &Release{
+			Semver:  v,
+			Version: strings.TrimPrefix(s, "v"),
+		})
+	}
+
+	return rs, nil
+}
+
+// NewStaticTracker returns the tracker of the StaticProvider for the versions
+func NewStaticTracker(versions []string, opts ...Option) (*Tracker, error) {
+	return New(Spec{}, append([]Option{Provider(NewStaticProvider(versions...))}, opts...)...)
+}
@@ -0,0 +1,61 @@
+package releasetracker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStaticTracker(t *testing.T) {
+	tracker, err := NewStaticTracker([]string{"v1.2.0", "1.10.0", "1.3.0-rc.1", "1.2.3.4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if kind := tracker.ProviderKind(); kind != "provider" {
+		t.Errorf("unexpected kind: expected=provider, got=%s", kind)
+	}
+
+	latest, err := tracker.Latest("< 1.10.0-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.3.0-rc.1" {
+		t.Errorf("unexpected latest: expected=1.3.0-rc.1, got=%s", latest.Version)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if got := strings.Join(vs, ","); got != "1.2.0,1.2.3.4,1.3.0-rc.1,1.10.0" {
+		t.Errorf("unexpected releases: %s", got)
+	}
+}
+
+func TestStaticProvider_InvalidVersion(t *testing.T) {
+	tracker, err := NewStaticTracker([]string{"1.0.0", "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tracker.GetReleases()
+	if err == nil || !strings.Contains(err.Error(), `index 1: "foo"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestProviderOption_WithVersionsSource(t *testing.T) {
+	spec := Spec{VersionsFrom: VersionsFrom{Exec: Exec{Command: "list-versions"}}}
+
+	_, err := New(spec, Provider(NewStaticProvider("1.0.0")))
+	if err == nil || !strings.Contains(err.Error(), "along with the provider option") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
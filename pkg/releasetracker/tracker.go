@@ -1,25 +1,29 @@
 package releasetracker
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"github.com/Masterminds/semver"
-	"github.com/PaesslerAG/jsonpath"
 	"github.com/go-logr/logr"
-	"github.com/heroku/docker-registry-client/registry"
 	"github.com/twpayne/go-vfs"
 	"github.com/variantdev/mod/pkg/cmdsite"
 	"github.com/variantdev/mod/pkg/depresolver"
 	"github.com/variantdev/mod/pkg/maputil"
 	"github.com/variantdev/mod/pkg/vhttpget"
 	"gopkg.in/yaml.v3"
-	"io/ioutil"
+	"io"
 	"k8s.io/klog/klogr"
-	"log"
+	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Release struct {
@@ -32,12 +36,29 @@ type Release struct {
 	// Version is mostly the original version string obtained from a release provider, with the "v" prefix removed
 	Version string
 
+	// Description is the release notes of the release, like the body of a GitHub release.
+	// Only the githubReleases and gitlabReleases providers set it at the moment.
 	Description string
 
+	// PublishedAt is when the release was published. Zero when the provider doesn't know it.
+	// Only the githubReleases, gitlabReleases, goProxy and dockerImageTags providers set it at the moment.
+	// dockerImageTags sets it to when the tag was last pushed.
+	PublishedAt time.Time
+
 	// Meta is the provider-specific metadata composed of arbitrary kv pairs
 	Meta map[string]interface{}
 }
 
+// CoreVersion returns the `major.minor.patch` part of the version, without prerelease and build metadata.
+// For "1.2.0-rc.1+build" this is "1.2.0".
+func (r *Release) CoreVersion() string {
+	return fmt.Sprintf("%d.%d.%d", r.Semver.Major(), r.Semver.Minor(), r.Semver.Patch())
+}
+
+func sameCore(a, b *semver.Version) bool {
+	return a.Major() == b.Major() && a.Minor() == b.Minor() && a.Patch() == b.Patch()
+}
+
 type Tracker struct {
 	Spec Spec
 
@@ -45,6 +66,10 @@ type Tracker struct {
 
 	cmdSite *cmdsite.CommandSite
 
+	// defaultCommander is true when the commands are run by the default runners of cmdsite,
+	// rather than the one set via Commander
+	defaultCommander bool
+
 	Logger logr.Logger
 
 	AbsWorkDir string
@@ -55,6 +80,58 @@ type Tracker struct {
 
 	httpGetter vhttpget.Getter
 
+	// httpTimeout is the timeout of the requests made by the default HTTP getter and the registry client
+	httpTimeout time.Duration
+
+	// httpClient is the client used by the default HTTP getter and the registry client instead of the default one
+	httpClient *http.Client
+
+	// httpProxy is the proxy set via Proxy, which overrides the proxy envvars
+	httpProxy *neturl.URL
+
+	// userAgent is sent as the `User-Agent` header of the requests that lack it. Empty means vhttpget.DefaultUserAgent
+	userAgent string
+
+	// httpCacheTTL is how long the HTTP responses are served from the disk cache. 0 disables the cache
+	httpCacheTTL time.Duration
+
+	// httpRetries is the number of retries on transient HTTP failures, starting with the wait of httpRetryBackoff
+	httpRetries      int
+	httpRetryBackoff time.Duration
+
+	// urlRewriter rewrites the URL of every outbound HTTP request but go-getter's and git's, so that requests can be routed through gateways
+	urlRewriter func(string) string
+
+	kvReader KVReader
+
+	s3Lister S3Lister
+
+	secretResolver SecretResolver
+
+	// provider is the one set via Provider to be used instead of the versions source
+	provider ReleaseProvider
+
+	middlewares []Middleware
+
+	reportMu        sync.Mutex
+	lastFetchReport FetchReport
+
+	// pollLimiter is the semaphore shared across all the watchers of this tracker
+	pollLimiter chan struct{}
+
+	// versionCapture is the compiled Spec.VersionCapture
+	versionCapture *regexp.Regexp
+
+	// filter is the compiled Spec.Filter
+	filter *regexp.Regexp
+
+	// excludeVersions and includeVersions are the parsed Spec.ExcludeVersions and Spec.IncludeVersions
+	excludeVersions []*semver.Version
+	includeVersions []*semver.Version
+
+	// versionLess reports whether the version a is older than b. Defaults to the semver precedence
+	versionLess func(a, b *semver.Version) bool
+
 	dep *depresolver.Resolver
 }
 
@@ -73,8 +150,18 @@ func New(conf Spec, opts ...Option) (*Tracker, error) {
 		}
 	}
 
+	if provider.provider != nil {
+		if err := conf.validateWithProvider(); err != nil {
+			return nil, err
+		}
+	} else if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
 	if provider.cmdSite.RunCmd == nil {
 		provider.cmdSite.RunCmd = cmdsite.DefaultRunCommand
+		provider.cmdSite.RunCmdContext = cmdsite.DefaultRunCommandContext
+		provider.defaultCommander = true
 	}
 
 	if provider.Logger == nil {
@@ -85,8 +172,36 @@ func New(conf Spec, opts ...Option) (*Tracker, error) {
 		provider.fs = vfs.HostOSFS
 	}
 
+	if provider.httpTimeout == 0 {
+		provider.httpTimeout = vhttpget.DefaultTimeout
+	}
+
+	if provider.httpProxy != nil {
+		if provider.httpClient != nil {
+			return nil, fmt.Errorf("proxy can't be set along with the http client: configure the transport of the client instead")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(provider.httpProxy)
+		provider.httpClient = &http.Client{Transport: transport, Timeout: provider.httpTimeout}
+	}
+
 	if provider.httpGetter == nil {
-		provider.httpGetter = vhttpget.New()
+		if provider.httpClient != nil {
+			provider.httpGetter = vhttpget.NewWithClient(provider.httpClient)
+		} else {
+			provider.httpGetter = vhttpget.NewWithTimeout(provider.httpTimeout)
+		}
+	}
+
+	if provider.urlRewriter == nil {
+		rewrites := os.Getenv("VARIANT_MOD_URL_REWRITES")
+		if rewrites != "" {
+			rewriter, err := urlPrefixRewriter(rewrites)
+			if err != nil {
+				return nil, fmt.Errorf("VARIANT_MOD_URL_REWRITES: %w", err)
+			}
+			provider.urlRewriter = rewriter
+		}
 	}
 
 	if provider.AbsWorkDir == "" {
@@ -140,30 +255,425 @@ func New(conf Spec, opts ...Option) (*Tracker, error) {
 
 	provider.Spec = conf
 
+	if conf.VersionCapture != "" {
+		re, err := regexp.Compile(conf.VersionCapture)
+		if err != nil {
+			return nil, fmt.Errorf("versionCapture: %w", err)
+		}
+		provider.versionCapture = re
+	}
+
+	if conf.Filter != "" {
+		re, err := regexp.Compile(conf.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %w", err)
+		}
+		provider.filter = re
+	}
+
+	if provider.excludeVersions, err = provider.parseVersionList("excludeVersions", conf.ExcludeVersions); err != nil {
+		return nil, err
+	}
+
+	if provider.includeVersions, err = provider.parseVersionList("includeVersions", conf.IncludeVersions); err != nil {
+		return nil, err
+	}
+
 	return provider, nil
 }
 
+// parseVersionList parses the versions listed in the field of the spec, like ExcludeVersions
+func (p *Tracker) parseVersionList(field string, versions []string) ([]*semver.Version, error) {
+	var vs []*semver.Version
+
+	for i, s := range versions {
+		v, err := p.parseVersion(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: parsing version %q: %v", field, i, s, err)
+		}
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
+// containsVersion reports whether the versions include the one of the same semver
+func containsVersion(vs []*semver.Version, v *semver.Version) bool {
+	for _, x := range vs {
+		if x.Equal(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func debug(msg string, v ...interface{}) {
 	if os.Getenv("DEBUG") != "" {
 		fmt.Fprintf(os.Stderr, msg+"\n", v...)
 	}
 }
 
+// urlPrefixRewriter builds a URL rewriter out of comma-separated `FROM=TO` pairs,
+// each replacing the URL prefix FROM with TO.
+//
+// For example, `https://api.github.com=https://gateway.internal/github` routes GitHub API requests through the gateway.
+func urlPrefixRewriter(rewrites string) (func(string) string, error) {
+	type rewrite struct {
+		from, to string
+	}
+
+	var rs []rewrite
+
+	for _, pair := range strings.Split(rewrites, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid url rewrite %q: it must be in the form of FROM=TO", pair)
+		}
+
+		rs = append(rs, rewrite{from: kv[0], to: kv[1]})
+	}
+
+	return func(u string) string {
+		for _, r := range rs {
+			if strings.HasPrefix(u, r.from) {
+				return r.to + strings.TrimPrefix(u, r.from)
+			}
+		}
+		return u
+	}, nil
+}
+
+// httpGetOnceContext is httpGetResponse that returns the context's error as soon as the context is done.
+// The in-flight request is aborted when the getter is a vhttpget.ContextGetter.
+// Otherwise the request can't be cancelled, and its result is discarded.
+func (p *Tracker) httpGetOnceContext(ctx context.Context, url string, opts ...vhttpget.Option) (*vhttpget.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if g, ok := p.httpGetter.(vhttpget.ContextGetter); ok {
+		u := p.rewriteURL(url)
+		opts = p.userAgentOptions(opts)
+
+		start := time.Now()
+
+		res, err := g.GetContext(ctx, u, opts...)
+		p.logHTTPResponse(u, start, res, err)
+		if err != nil && ctx.Err() != nil {
+			return nil, fmt.Errorf("getting %s: %w", url, ctx.Err())
+		}
+
+		return res, err
+	}
+
+	type result struct {
+		res *vhttpget.Response
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	go func() {
+		res, err := p.httpGetResponse(url, opts...)
+		ch <- result{res: res, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("getting %s: %w", url, ctx.Err())
+	case r := <-ch:
+		return r.res, r.err
+	}
+}
+
+func (p *Tracker) httpGet(url string, opts ...vhttpget.Option) (string, error) {
+	res, err := p.httpGetResponse(url, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Body, nil
+}
+
+func (p *Tracker) rewriteURL(url string) string {
+	if p.urlRewriter == nil {
+		return url
+	}
+
+	rewritten := p.urlRewriter(url)
+	if rewritten != url {
+		p.Logger.V(1).Info("rewrote url", "from", url, "to", rewritten)
+	}
+
+	return rewritten
+}
+
+// headerOptions turns the headers into the request options, expanding environment variables like `$API_KEY` in the values
+// so that secrets needn't be written in the config
+func headerOptions(headers map[string]string) []vhttpget.Option {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var opts []vhttpget.Option
+
+	for _, k := range keys {
+		opts = append(opts, vhttpget.Header(k, os.ExpandEnv(headers[k])))
+	}
+
+	return opts
+}
+
+// userAgentOptions adds the `User-Agent` header set via UserAgent to the request options, unless they have one
+func (p *Tracker) userAgentOptions(opts []vhttpget.Option) []vhttpget.Option {
+	if p.userAgent == "" {
+		return opts
+	}
+
+	o := &vhttpget.Opts{}
+	for _, opt := range opts {
+		opt.Set(o)
+	}
+
+	if o.Headers.Get("User-Agent") != "" {
+		return opts
+	}
+
+	return append([]vhttpget.Option{vhttpget.Header("User-Agent", p.userAgent)}, opts...)
+}
+
+// httpGetResponse is httpGet that returns the whole response.
+// The response lacks the status and the headers when the getter is not a vhttpget.ResponseGetter.
+func (p *Tracker) httpGetResponse(url string, opts ...vhttpget.Option) (*vhttpget.Response, error) {
+	url = p.rewriteURL(url)
+	opts = p.userAgentOptions(opts)
+
+	start := time.Now()
+
+	if g, ok := p.httpGetter.(vhttpget.ResponseGetter); ok {
+		res, err := g.Get(url, opts...)
+		p.logHTTPResponse(url, start, res, err)
+		return res, err
+	}
+
+	body, err := p.httpGetter.DoRequest(url, opts...)
+	if err != nil {
+		p.logHTTPResponse(url, start, nil, err)
+		return nil, err
+	}
+
+	res := &vhttpget.Response{Body: body}
+	p.logHTTPResponse(url, start, res, nil)
+
+	return res, nil
+}
+
+// logHTTPResponse logs the status and the size of the response for diagnosing slow or stale providers.
+// The status is 0 for getters that don't return the whole response
+func (p *Tracker) logHTTPResponse(url string, start time.Time, res *vhttpget.Response, err error) {
+	d := time.Since(start)
+
+	if err != nil {
+		p.Logger.V(1).Info("http get failed", "url", url, "duration", d, "error", err.Error())
+		return
+	}
+
+	p.Logger.V(1).Info("http get", "url", url, "status", res.StatusCode, "bytes", len(res.Body), "duration", d)
+}
+
 func (p *Tracker) Latest(constraint string) (*Release, error) {
-	all, err := p.GetReleases()
+	return p.LatestContext(context.Background(), constraint)
+}
+
+// LatestContext is Latest that gives up fetching releases once the context is done.
+// An invalid constraint is reported before fetching releases.
+func (p *Tracker) LatestContext(ctx context.Context, constraint string) (*Release, error) {
+	if err := ValidateConstraint(constraint); err != nil {
+		return nil, err
+	}
+
+	if constraint == "" && p.latestFastPathApplicable() {
+		if r, ok := p.latestFromSource(ctx); ok {
+			return r, nil
+		}
+	}
+
+	all, err := p.getReleases(ctx, namesPrerelease(constraint))
 	if err != nil {
 		return nil, err
 	}
 
-	return getLatest(constraint, all)
+	return p.latestOf(constraint, all)
 }
 
-func getLatest(constraint string, all []*Release) (*Release, error) {
+// latestReleaseProvider is implemented by the providers that can ask the source for the latest release
+// with a single request, rather than listing all the releases
+type latestReleaseProvider interface {
+	// latestRelease returns the latest release decided by the source. ok is false when the source can't tell
+	latestRelease(ctx context.Context) (r *Release, ok bool, err error)
+}
+
+// latestFastPathApplicable reports whether Spec.LatestFastPath can be honored. The fast path is skipped when
+// the comparator, MinReleases, DuplicatePolicy or ExcludePrereleases is configured, as the single release decided
+// by the source can't be checked against them
+func (p *Tracker) latestFastPathApplicable() bool {
+	return p.Spec.LatestFastPath &&
+		p.versionLess == nil &&
+		p.Spec.MinReleases == 0 &&
+		p.Spec.DuplicatePolicy == "" &&
+		!p.Spec.ExcludePrereleases
+}
+
+// latestFromSource returns the latest release decided by the source for Spec.LatestFastPath.
+// ok is false when the source doesn't support it or failed, so that the caller falls back to listing all the releases
+func (p *Tracker) latestFromSource(ctx context.Context) (*Release, bool) {
+	_, pp, err := p.resolveBaseProvider(p.Spec.VersionsFrom)
+	if err != nil {
+		return nil, false
+	}
+
+	lp, ok := pp.(latestReleaseProvider)
+	if !ok {
+		return nil, false
+	}
+
+	r, ok, err := lp.latestRelease(ctx)
+	if err != nil {
+		p.Logger.V(1).Info("getting the latest release failed. falling back to listing all the releases", "error", err.Error())
+		return nil, false
+	}
+
+	if !ok || len(p.filterReleases([]*Release{r})) == 0 {
+		return nil, false
+	}
+
+	return r, true
+}
+
+// LatestN returns up to n releases satisfying the constraint, in descending order of versions.
+// It returns fewer than n releases when fewer match.
+func (p *Tracker) LatestN(constraint string, n int) ([]*Release, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be greater than 0: got %d", n)
+	}
+
+	all, err := p.getReleases(context.Background(), namesPrerelease(constraint))
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := matchingReleases(constraint, all)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Spec.PreserveOrder {
+		if len(matched) > n {
+			matched = matched[:n]
+		}
+		return matched, nil
+	}
+
+	var rs []*Release
+
+	for i := len(matched) - 1; i >= 0 && len(rs) < n; i-- {
+		rs = append(rs, matched[i])
+	}
+
+	return rs, nil
+}
+
+// DefaultConstraint is the constraint used in place of the empty one, which matches all the releases including prereleases
+const DefaultConstraint = "> 0.0.0-0"
+
+// ValidateConstraint returns an error when the constraint, like `>= 1.2, < 2`, can't be parsed, so that a constraint
+// given by the user can be checked before fetching releases. The empty constraint is valid, meaning DefaultConstraint.
+func ValidateConstraint(constraint string) error {
+	_, err := parseConstraint(constraint)
+
+	return err
+}
+
+func parseConstraint(constraint string) (*semver.Constraints, error) {
 	if constraint == "" {
-		constraint = "> 0.0.0-0"
+		constraint = DefaultConstraint
 	}
 
 	cons, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %w", constraint, err)
+	}
+
+	return cons, nil
+}
+
+// matchingReleases returns the releases matching the constraint, preserving the order.
+// An empty constraint matches all the releases including prereleases, as in Latest.
+func matchingReleases(constraint string, all []*Release) ([]*Release, error) {
+	cons, err := parseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []*Release
+
+	for _, r := range all {
+		if cons.Check(r.Semver) {
+			rs = append(rs, r)
+		}
+	}
+
+	return rs, nil
+}
+
+func (p *Tracker) preferStableOnTie() bool {
+	return p.Spec.PreferStableOnTie == nil || *p.Spec.PreferStableOnTie
+}
+
+func (p *Tracker) skipInvalidVersions() bool {
+	return p.Spec.SkipInvalidVersions == nil || *p.Spec.SkipInvalidVersions
+}
+
+// latestOf returns the latest release matching the constraint, which is the first one in the order of the source
+// when Spec.PreserveOrder is set
+func (p *Tracker) latestOf(constraint string, all []*Release) (*Release, error) {
+	if !p.Spec.PreserveOrder {
+		return pickLatest(constraint, all, p.preferStableOnTie(), p.less)
+	}
+
+	rs, err := matchingReleases(constraint, all)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rs) == 0 {
+		vers := []string{}
+		for _, r := range all {
+			vers = append(vers, r.Semver.String())
+		}
+		return nil, &NoMatchingReleaseError{Constraint: constraint, Versions: vers}
+	}
+
+	return rs[0], nil
+}
+
+func getLatest(constraint string, all []*Release) (*Release, error) {
+	return pickLatest(constraint, all, true, semverLess)
+}
+
+// pickLatest returns the release with the highest precedence by less among the ones matching the constraint.
+// When preferStable is false, a prerelease wins over the stable release of the same core version.
+func pickLatest(constraint string, all []*Release, preferStable bool, less func(a, b *semver.Version) bool) (*Release, error) {
+	cons, err := parseConstraint(constraint)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +688,15 @@ func getLatest(constraint string, all []*Release) (*Release, error) {
 			continue
 		}
 
-		if latestVer.LessThan(r.Semver) {
+		if latest != nil && !preferStable && sameCore(latest.Semver, r.Semver) {
+			if r.Semver.Prerelease() != "" && (latest.Semver.Prerelease() == "" || less(&latestVer, r.Semver)) {
+				latestVer = *r.Semver
+				latest = r
+			}
+			continue
+		}
+
+		if less(&latestVer, r.Semver) {
 			latestVer = *r.Semver
 			latest = r
 		}
@@ -189,7 +707,7 @@ func getLatest(constraint string, all []*Release) (*Release, error) {
 		for _, r := range all {
 			vers = append(vers, r.Semver.String())
 		}
-		return nil, fmt.Errorf("no semver matching %q found in %v", constraint, vers)
+		return nil, &NoMatchingReleaseError{Constraint: constraint, Versions: vers}
 	}
 
 	return latest, nil
@@ -199,17 +717,18 @@ type ReleaseProvider interface {
 	All() ([]*Release, error)
 }
 
-func newExecProvider(cmd string, args []string, r *Tracker) *execProvider {
-	return &execProvider{
-		command: cmd,
-		args:    args,
-		runtime: r,
-	}
+// ContextReleaseProvider is implemented by providers that can stop fetching releases when the context is done.
+// Providers that don't implement it are abandoned, rather than cancelled, once the context is done.
+type ContextReleaseProvider interface {
+	AllContext(ctx context.Context) ([]*Release, error)
 }
 
-func newShellProvider(cmd string, r *Tracker) *shellProvider {
-	return &shellProvider{
-		script:  cmd,
+func newExecProvider(spec Exec, r *Tracker) *execProvider {
+	return &execProvider{
+		command: spec.Command,
+		args:    spec.Args,
+		env:     spec.Env,
+		dir:     spec.Dir,
 		runtime: r,
 	}
 }
@@ -222,40 +741,242 @@ func newGetterProvider(spec GetterJSONPath, r *Tracker) *getterJsonPathProvider
 }
 
 func newDockerHubImageTagsProvider(spec DockerImageTags, r *Tracker) *dockerImageTagsProvider {
+	source := normalizeDockerHubSource(spec.Source)
+
 	return &dockerImageTagsProvider{
-		source:  spec.Source,
+		source: source,
+		hub: overrideVersionsPath(&httpJsonPathProvider{
+			url:             fmt.Sprintf("https://registry.hub.docker.com/v2/repositories/%s/tags/", source),
+			jsonpath:        "$.results[*].name",
+			metaKey:         "dockerHubTag",
+			objectPath:      "$.results[*]",
+			versionPath:     "name",
+			publishedAtPath: "last_updated",
+			nextpagePath:    "$.next",
+			params:          map[string]string{"page_size": "1000"},
+			maxPages:        spec.MaxPages,
+			runtime:         r,
+		}, spec.Versions),
 		runtime: r,
 	}
 }
 
+// skipGitHubRelease returns the filter of the GitHub release objects by their `draft` and `prerelease` flags.
+// This is driven by the flags set on GitHub, which differ from the semver prerelease part of the tag name.
+func skipGitHubRelease(spec GitHubReleases) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		if draft, _ := m["draft"].(bool); draft && !spec.IncludeDrafts {
+			return true
+		}
+
+		if prerelease, _ := m["prerelease"].(bool); prerelease && !spec.IncludePrereleases {
+			return true
+		}
+
+		return false
+	}
+}
+
+// overrideVersionsPath makes the provider extract versions with the jsonpath, instead of mapping objects to releases,
+// when the jsonpath is not empty
+func overrideVersionsPath(pp *httpJsonPathProvider, jsonpath string) *httpJsonPathProvider {
+	if jsonpath != "" {
+		pp.jsonpath = jsonpath
+		pp.objectPath = ""
+		pp.versionPath = ""
+		pp.metaKey = ""
+	}
+
+	return pp
+}
+
+// githubAPIURL returns the base URL of the GitHub API, like `https://github.example.com/api/v3` for GitHub Enterprise Server
+func githubAPIURL(host, apiPath string) string {
+	if host == "" {
+		host = "api.github.com"
+	}
+
+	apiPath = strings.Trim(apiPath, "/")
+	if apiPath == "" {
+		return "https://" + host
+	}
+
+	return "https://" + host + "/" + apiPath
+}
+
 func newGitHubReleasesProvider(spec GitHubReleases, r *Tracker) *httpJsonPathProvider {
+	url := fmt.Sprintf("%s/repos/%s/releases", githubAPIURL(spec.Host, spec.APIPath), normalizeGitHubSource(spec.Source, spec.Host))
+
+	// The latest release on GitHub is never a draft nor a prerelease
+	var latestURL string
+	if !spec.IncludeDrafts && !spec.IncludePrereleases {
+		latestURL = url + "/latest"
+	}
+
+	return overrideVersionsPath(&httpJsonPathProvider{
+		url:             url,
+		jsonpath:        "$[*].tag_name",
+		metaKey:         "githubRelease",
+		objectPath:      "$[*]",
+		versionPath:     "tag_name",
+		publishedAtPath: "published_at",
+		descriptionPath: "body",
+		skipObject:      skipGitHubRelease(spec),
+		followLinks:     true,
+		maxPages:        spec.MaxPages,
+		token:           spec.Token,
+		headers:         spec.Headers,
+		checkResponse:   r.checkGitHubRateLimit,
+		latestURL:       latestURL,
+		runtime:         r,
+	}, spec.Versions)
+}
+
+// githubRateLimitLow is the number of the remaining requests below which the rate limit is logged
+const githubRateLimitLow = 10
+
+// checkGitHubRateLimit returns a RateLimitError when the GitHub API refused the request due to the rate limit,
+// rather than letting the error body be parsed as a page of releases
+func (p *Tracker) checkGitHubRateLimit(url string, res *vhttpget.Response) error {
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+
+	var reset time.Time
+
+	if sec, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(sec, 0)
+	}
+
+	if remaining == 0 && (res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusTooManyRequests) {
+		return &RateLimitError{URL: url, Reset: reset}
+	}
+
+	if remaining < githubRateLimitLow {
+		p.Logger.V(1).Info("github api rate limit running low", "url", url, "remaining", remaining, "reset", reset)
+	}
+
+	return nil
+}
+
+func newHTTPJSONPathProvider(spec HTTPJSONPath, r *Tracker) *httpJsonPathProvider {
+	pp := &httpJsonPathProvider{
+		url:         spec.URL,
+		jsonpath:    spec.Versions,
+		jq:          spec.JQ,
+		cursor:      spec.Cursor,
+		headers:     spec.Headers,
+		token:       spec.BearerToken,
+		tokenScheme: "Bearer",
+		runtime:     r,
+	}
+
+	if spec.Objects.Path != "" {
+		pp.metaKey = "httpJSONPath"
+		pp.objectPath = spec.Objects.Path
+		pp.versionPath = spec.Objects.Version
+		pp.publishedAtPath = spec.Objects.PublishedAt
+		pp.descriptionPath = spec.Objects.Description
+	}
+
+	return pp
+}
+
+func newBitbucketTagsProvider(spec BitbucketTags, r *Tracker) *httpJsonPathProvider {
+	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/refs/tags", spec.Workspace, spec.RepoSlug)
+
+	return &httpJsonPathProvider{
+		url:             url,
+		jsonpath:        "$.values[*].name",
+		metaKey:         "bitbucketTag",
+		objectPath:      "$.values[*]",
+		versionPath:     "name",
+		publishedAtPath: "target.date",
+		nextpagePath:    "$.next",
+		params:          map[string]string{"pagelen": "100"},
+		maxPages:        spec.MaxPages,
+		token:           spec.Token,
+		tokenScheme:     "Bearer",
+		headers:         spec.Headers,
+		runtime:         r,
+	}
+}
+
+func newGitLabReleasesProvider(spec GitLabReleases, r *Tracker) *httpJsonPathProvider {
 	host := spec.Host
 	if host == "" {
-		host = "api.github.com"
+		host = "gitlab.com"
+	}
+
+	id := spec.ProjectID
+	if id == "" {
+		id = neturl.PathEscape(normalizeGitLabSource(spec.Source, host))
 	}
-	url := fmt.Sprintf("https://%s/repos/%s/releases", host, spec.Source)
+
+	url := fmt.Sprintf("https://%s/api/v4/projects/%s/releases", host, id)
 
 	return &httpJsonPathProvider{
-		url:         url,
-		jsonpath:    "$[*].tag_name",
-		metaKey:     "githubRelease",
-		objectPath:  "$[*]",
-		versionPath: "tag_name",
-		runtime:     r,
+		url:             url,
+		jsonpath:        "$[*].tag_name",
+		metaKey:         "gitlabRelease",
+		objectPath:      "$[*]",
+		versionPath:     "tag_name",
+		publishedAtPath: "released_at",
+		descriptionPath: "description",
+		headers:         spec.Headers,
+		runtime:         r,
 	}
 }
 
-func newGitHubTagsProvider(spec GitHubTags, r *Tracker) *httpJsonPathProvider {
+func newGitLabTagsProvider(spec GitLabTags, r *Tracker) *httpJsonPathProvider {
 	host := spec.Host
 	if host == "" {
-		host = "api.github.com"
+		host = "gitlab.com"
+	}
+
+	id := spec.ProjectID
+	if id == "" {
+		id = neturl.PathEscape(normalizeGitLabSource(spec.Source, host))
 	}
-	url := fmt.Sprintf("https://%s/repos/%s/tags", host, spec.Source)
+
+	url := fmt.Sprintf("https://%s/api/v4/projects/%s/repository/tags", host, id)
+
+	return &httpJsonPathProvider{
+		url:             url,
+		jsonpath:        "$[*].name",
+		metaKey:         "gitlabTag",
+		objectPath:      "$[*]",
+		versionPath:     "name",
+		publishedAtPath: "commit.created_at",
+		params:          map[string]string{"per_page": "100"},
+		followLinks:     true,
+		nextPageHeader:  "X-Next-Page",
+		maxPages:        spec.MaxPages,
+		token:           spec.Token,
+		tokenScheme:     "Bearer",
+		headers:         spec.Headers,
+		runtime:         r,
+	}
+}
+
+func newGitHubTagsProvider(spec GitHubTags, r *Tracker) *httpJsonPathProvider {
+	url := fmt.Sprintf("%s/repos/%s/tags", githubAPIURL(spec.Host, spec.APIPath), normalizeGitHubSource(spec.Source, spec.Host))
 
 	return &httpJsonPathProvider{
-		url:      url,
-		jsonpath: "$[*].name",
-		runtime:  r,
+		url:           url,
+		jsonpath:      "$[*].name",
+		followLinks:   true,
+		maxPages:      spec.MaxPages,
+		token:         spec.Token,
+		headers:       spec.Headers,
+		checkResponse: r.checkGitHubRateLimit,
+		runtime:       r,
 	}
 }
 
@@ -263,25 +984,66 @@ type execProvider struct {
 	command string
 	args    []string
 
+	// env is added to the environment of the command, after expanding environment variables in the values
+	env map[string]string
+
+	// dir is the working directory of the command. Relative to the tracker's work dir
+	dir string
+
 	runtime *Tracker
 }
 
 var _ ReleaseProvider = &execProvider{}
+var _ ContextReleaseProvider = &execProvider{}
 
 func (p *execProvider) All() ([]*Release, error) {
-	return p.runtime.releasesFromExec(p.command, p.args)
+	return p.AllContext(context.Background())
 }
 
-type shellProvider struct {
-	script string
+func (p *execProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	site, err := p.site()
+	if err != nil {
+		return nil, err
+	}
+
+	vs, err := p.runtime.execIn(ctx, site, p.command, p.args)
+	if err != nil {
+		return nil, err
+	}
 
-	runtime *Tracker
+	return p.runtime.versionsToReleases(vs)
 }
 
-var _ ReleaseProvider = &shellProvider{}
+// site returns the command site to run the command with the env and in the dir
+func (p *execProvider) site() (*cmdsite.CommandSite, error) {
+	site := p.runtime.cmdSite
+
+	if len(p.env) > 0 {
+		env := map[string]string{}
+		for k, v := range p.env {
+			env[k] = os.ExpandEnv(v)
+		}
+
+		site = site.SetEnv(env)
+	}
+
+	if p.dir != "" {
+		if !p.runtime.defaultCommander {
+			return nil, fmt.Errorf("exec: dir %q is not supported with the command runner set via Commander", p.dir)
+		}
+
+		dir := p.dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(p.runtime.AbsWorkDir, dir)
+		}
+
+		s := *site
+		s.RunCmd = cmdsite.DefaultRunCommandIn(dir)
+		s.RunCmdContext = cmdsite.DefaultRunCommandContextIn(dir)
+		site = &s
+	}
 
-func (p *shellProvider) All() ([]*Release, error) {
-	return p.runtime.releasesFromShell(p.script)
+	return site, nil
 }
 
 type getterJsonPathProvider struct {
@@ -292,6 +1054,10 @@ type getterJsonPathProvider struct {
 
 var _ ReleaseProvider = &getterJsonPathProvider{}
 
+func (p *getterJsonPathProvider) sourceURL() string {
+	return p.spec.Source
+}
+
 func (p *getterJsonPathProvider) All() ([]*Release, error) {
 	return p.runtime.releasesFromGetterJsonPath(p.spec)
 }
@@ -301,25 +1067,46 @@ type dockerImageTagsProvider struct {
 	username string
 	password string
 
+	// hub lists tags via the Docker Hub API, following the `next` URL of each page.
+	// It is used unless the credentials are provided, as the API doesn't require them for public images
+	hub *httpJsonPathProvider
+
 	runtime *Tracker
 }
 
+var _ ContextReleaseProvider = &dockerImageTagsProvider{}
+
+func (p *dockerImageTagsProvider) sourceURL() string {
+	return p.hub.url
+}
+
 func (p *dockerImageTagsProvider) All() ([]*Release, error) {
-	if p.username == "" {
-		p.username = os.Getenv("DOCKER_USERNAME")
+	return p.AllContext(context.Background())
+}
+
+// credentials returns the username and the password to list tags with, which default to DOCKER_USERNAME and DOCKER_PASSWORD
+func (p *dockerImageTagsProvider) credentials() (string, string) {
+	username, password := p.username, p.password
+	if username == "" {
+		username = os.Getenv("DOCKER_USERNAME")
 	}
-	if p.password == "" {
-		p.password = os.Getenv("DOCKER_PASSWORD")
+	if password == "" {
+		password = os.Getenv("DOCKER_PASSWORD")
 	}
-	w := log.Writer()
-	log.SetOutput(ioutil.Discard)
-	defer log.SetOutput(w)
-	hub, err := registry.New("https://registry.hub.docker.com/", p.username, p.password)
+	return username, password
+}
+
+func (p *dockerImageTagsProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	username, password := p.credentials()
+	if username == "" && password == "" {
+		return p.hub.AllContext(ctx)
+	}
+	password, err := p.runtime.resolveSecret(password)
 	if err != nil {
 		return nil, err
 	}
 
-	tags, err := hub.Tags(p.source)
+	tags, err := p.runtime.registryTags("https://registry.hub.docker.com", p.source, username, password)
 	if err != nil {
 		return nil, err
 	}
@@ -341,21 +1128,90 @@ type httpJsonPathProvider struct {
 	objectPath  string
 	versionPath string
 
+	// publishedAtPath is the jsonpath to the RFC3339 timestamp of the release relative to each object
+	publishedAtPath string
+
+	// descriptionPath is the jsonpath to the description of the release relative to each object, like the release notes
+	descriptionPath string
+
+	// skipObject reports whether the object is excluded from the releases before its version is parsed
+	skipObject func(obj interface{}) bool
+
+	cursor CursorPagination
+
+	// followLinks makes the provider follow the `rel="next"` link in the Link header of each page, as GitHub API paginates
+	followLinks bool
+
+	// nextPageHeader is the header holding the number of the next page, like GitLab's `X-Next-Page`,
+	// which is requested via the `page` param when the page lacks the next link
+	nextPageHeader string
+
+	// maxPages caps the number of pages fetched. Defaults to DefaultMaxPages
+	maxPages int
+
+	// token is sent as `Authorization: token <token>` header. It is resolved with the SecretResolver
+	token string
+
+	// tokenScheme replaces the `token` scheme of the Authorization header, like `Bearer`
+	tokenScheme string
+
+	// headers are sent with every request, after expanding environment variables in the values
+	headers map[string]string
+
+	// checkResponse returns an error for the response that isn't a page of releases, like the one for rate limiting
+	checkResponse func(url string, res *vhttpget.Response) error
+
+	// jq is the jq query to extract versions from each page. Takes precedence over jsonpath
+	jq string
+
+	// latestURL returns the latest release decided by the source as a single object, like GitHub's `releases/latest`.
+	// Empty when the source has no such endpoint
+	latestURL string
+
 	runtime *Tracker
 }
 
 var _ ReleaseProvider = &httpJsonPathProvider{}
 
+func (p *httpJsonPathProvider) sourceURL() string {
+	return p.url
+}
+
+func (p *httpJsonPathProvider) latestRelease(ctx context.Context) (*Release, bool, error) {
+	if p.latestURL == "" || p.metaKey == "" {
+		return nil, false, nil
+	}
+
+	latest := *p
+	latest.url = p.latestURL
+	latest.objectPath = "$"
+	latest.followLinks = false
+	latest.nextpagePath = ""
+	latest.cursor = CursorPagination{}
+
+	rs, err := p.runtime.releasesFromHttpJsonPath(ctx, &latest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rs[0], true, nil
+}
+
 func (p *httpJsonPathProvider) All() ([]*Release, error) {
-	return p.runtime.releasesFromHttpJsonPath(p)
+	return p.runtime.releasesFromHttpJsonPath(context.Background(), p)
+}
+
+func (p *httpJsonPathProvider) AllContext(ctx context.Context) ([]*Release, error) {
+	return p.runtime.releasesFromHttpJsonPath(ctx, p)
 }
 
-func (p *Tracker) execScript(cmd string) ([]string, error) {
-	return p.exec("sh", []string{"-c", cmd})
+func (p *Tracker) exec(ctx context.Context, cmd string, args []string) ([]string, error) {
+	return p.execIn(ctx, p.cmdSite, cmd, args)
 }
 
-func (p *Tracker) exec(cmd string, args []string) ([]string, error) {
-	stdout, stderr, err := p.cmdSite.CaptureStrings(cmd, args)
+// execIn is exec that runs the command via the site
+func (p *Tracker) execIn(ctx context.Context, site *cmdsite.CommandSite, cmd string, args []string) ([]string, error) {
+	stdout, stderr, err := site.CaptureStringsContext(ctx, cmd, args)
 	if len(stderr) > 0 {
 		p.Logger.V(1).Info(stderr)
 	}
@@ -368,16 +1224,52 @@ func (p *Tracker) exec(cmd string, args []string) ([]string, error) {
 	vs := []string{}
 
 	for _, e := range entries {
-		if e != "" {
+		if e = strings.TrimSuffix(e, "\r"); e != "" {
 			vs = append(vs, e)
 		}
 	}
 
-	return vs, nil
-}
+	return vs, nil
+}
+
+func (p *Tracker) releasesFromGetterJsonPath(spec GetterJSONPath) ([]*Release, error) {
+	if spec.Files != "" {
+		if spec.Objects.Path != "" {
+			return nil, fmt.Errorf("objects can't be used with files: %s", spec.Source)
+		}
+		return p.releasesFromGetterFiles(spec)
+	}
+
+	var bs []byte
+
+	if len(spec.Headers) > 0 {
+		if !strings.HasPrefix(spec.Source, "http://") && !strings.HasPrefix(spec.Source, "https://") {
+			return nil, fmt.Errorf("headers are supported only for http and https sources: %s", spec.Source)
+		}
+
+		res, err := p.httpGetResponse(spec.Source, headerOptions(spec.Headers)...)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = []byte(res.Body)
+	} else {
+		localCopy, err := p.dep.ResolveFile(spec.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		bs, err = p.fs.ReadFile(localCopy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Objects.Path != "" {
+		return p.releasesFromDocumentObjects(bs, spec.Objects)
+	}
 
-func (p *Tracker) releasesFromExec(cmd string, args []string) ([]*Release, error) {
-	vs, err := p.exec(cmd, args)
+	vs, err := p.queryDocuments(bs, versionsQuery{jsonpath: spec.Versions, jq: spec.JQ})
 	if err != nil {
 		return nil, err
 	}
@@ -385,35 +1277,91 @@ func (p *Tracker) releasesFromExec(cmd string, args []string) ([]*Release, error
 	return p.versionsToReleases(vs)
 }
 
-func (p *Tracker) releasesFromShell(cmd string) ([]*Release, error) {
-	vs, err := p.execScript(cmd)
-	if err != nil {
-		return nil, err
+// releasesFromDocumentObjects extracts releases from the objects in the JSON or YAML data, like versionsFromDocuments.
+func (p *Tracker) releasesFromDocumentObjects(bs []byte, objs ReleaseObjects) ([]*Release, error) {
+	pp := &httpJsonPathProvider{
+		metaKey:         "jsonPath",
+		objectPath:      objs.Path,
+		versionPath:     objs.Version,
+		publishedAtPath: objs.PublishedAt,
+		descriptionPath: objs.Description,
 	}
 
-	return p.versionsToReleases(vs)
-}
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
 
-func (p *Tracker) releasesFromGetterJsonPath(spec GetterJSONPath) ([]*Release, error) {
-	localCopy, err := p.dep.ResolveFile(spec.Source)
-	if err != nil {
-		return nil, err
+	var rs []*Release
+
+	var items int
+
+	for i := 0; ; i++ {
+		tmp := interface{}(nil)
+		if err := dec.Decode(&tmp); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		if tmp == nil {
+			continue
+		}
+
+		docReleases, n, err := p.extractObjects(tmp, pp)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		items += n
+
+		rs = append(rs, docReleases...)
 	}
 
-	bs, err := p.fs.ReadFile(localCopy)
-	if err != nil {
-		return nil, err
+	if len(rs) == 0 && items > 0 {
+		return nil, noValidVersionsError(items, objs.Version, objs.Path)
 	}
 
-	tmp := interface{}(nil)
-	if err := yaml.Unmarshal(bs, &tmp); err != nil {
-		return nil, err
+	p.sortReleases(rs)
+
+	return rs, nil
+}
+
+// versionsFromDocuments extracts versions from the JSON or YAML data.
+// The data can be a multi-document YAML. Each document is queried by the jsonpath, and the results are merged.
+func (p *Tracker) versionsFromDocuments(bs []byte, jpath string) ([]string, error) {
+	return p.queryDocuments(bs, versionsQuery{jsonpath: jpath})
+}
+
+// queryDocuments is versionsFromDocuments that queries each document by either the jsonpath or the jq query
+func (p *Tracker) queryDocuments(bs []byte, q versionsQuery) ([]string, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(bs))
+
+	var vs []string
+
+	for i := 0; ; i++ {
+		tmp := interface{}(nil)
+		if err := dec.Decode(&tmp); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		if tmp == nil {
+			continue
+		}
+
+		docVersions, err := p.queryVersionStrings(tmp, q)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+
+		vs = append(vs, docVersions...)
 	}
 
-	return p.extractVersions(tmp, spec.Versions)
+	return vs, nil
 }
 
-func (p *Tracker) releasesFromHttpJsonPath(pp *httpJsonPathProvider) ([]*Release, error) {
+func (p *Tracker) releasesFromHttpJsonPath(ctx context.Context, pp *httpJsonPathProvider) ([]*Release, error) {
 	url := pp.url
 	jpath := pp.jsonpath
 	nextpagePath := pp.nextpagePath
@@ -427,6 +1375,31 @@ func (p *Tracker) releasesFromHttpJsonPath(pp *httpJsonPathProvider) ([]*Release
 		query += k + "=" + v
 	}
 
+	opts := headerOptions(pp.headers)
+
+	if pp.token != "" {
+		token, err := p.resolveSecret(pp.token)
+		if err != nil {
+			return nil, err
+		}
+
+		scheme := pp.tokenScheme
+		if scheme == "" {
+			scheme = "token"
+		}
+
+		opts = append(opts, vhttpget.Header("Authorization", scheme+" "+token))
+	}
+
+	var prevCursor string
+
+	maxPages := pp.maxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	var pages, items int
+
 	var releases []*Release
 	for url != "" {
 		var u string
@@ -441,11 +1414,29 @@ func (p *Tracker) releasesFromHttpJsonPath(pp *httpJsonPathProvider) ([]*Release
 		}
 		debug("http get: %s", u)
 
-		res, err := p.httpGetter.DoRequest(u)
+		if pages >= maxPages {
+			p.Logger.V(1).Info("reached the max number of pages. ignoring remaining pages", "maxPages", maxPages, "next", u)
+			break
+		}
+		pages++
+
+		resp, err := p.httpGetCachedContext(ctx, u, opts...)
 		if err != nil {
+			if ctx.Err() != nil && p.Spec.PartialResultsOnDeadline && len(releases) > 0 {
+				p.Logger.V(1).Info("returning partial results", "error", err.Error(), "count", len(releases))
+				return releases, nil
+			}
 			return nil, err
 		}
 
+		if pp.checkResponse != nil {
+			if err := pp.checkResponse(u, resp); err != nil {
+				return nil, err
+			}
+		}
+
+		res := resp.Body
+
 		tmp := interface{}(nil)
 		if err := yaml.Unmarshal([]byte(res), &tmp); err != nil {
 			return nil, err
@@ -454,14 +1445,21 @@ func (p *Tracker) releasesFromHttpJsonPath(pp *httpJsonPathProvider) ([]*Release
 		debug("http response: %v", res)
 
 		if pp.objectPath != "" && pp.versionPath != "" && pp.metaKey != "" {
-			page, err := p.extractObjects(tmp, pp.objectPath, pp.versionPath, pp.metaKey)
+			page, n, err := p.extractObjects(tmp, pp)
 			if err != nil {
 				return nil, err
 			}
 
+			items += n
+
 			releases = append(releases, page...)
 		} else {
-			page, err := p.extractVersions(tmp, jpath)
+			vs, err := p.queryVersionStrings(tmp, versionsQuery{jsonpath: jpath, jq: pp.jq})
+			if err != nil {
+				return nil, err
+			}
+
+			page, err := p.versionsToReleases(vs)
 			if err != nil {
 				return nil, err
 			}
@@ -469,30 +1467,92 @@ func (p *Tracker) releasesFromHttpJsonPath(pp *httpJsonPathProvider) ([]*Release
 			releases = append(releases, page...)
 		}
 
+		if pp.cursor.Path != "" {
+			cursor, err := p.extractString(tmp, pp.cursor.Path)
+			if err != nil {
+				p.Logger.V(1).Info("no cursor found in the page. assuming it is the last page", "path", pp.cursor.Path, "error", err.Error())
+				break
+			}
+
+			if cursor == "" {
+				break
+			}
+
+			if cursor == prevCursor {
+				return nil, fmt.Errorf("cursor %q at %q did not change across pages", cursor, pp.cursor.Path)
+			}
+			prevCursor = cursor
+
+			url, err = nextCursorPageURL(pp.url, cursor, pp.cursor)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if pp.followLinks {
+			url = nextLink(resp.Header.Get("Link"))
+
+			if page := resp.Header.Get(pp.nextPageHeader); url == "" && pp.nextPageHeader != "" && page != "" {
+				url, err = setQueryParam(u, "page", page)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
 		if nextpagePath == "" {
 			break
 		}
 
 		nextUrl, err := p.extractString(tmp, nextpagePath)
 		if err != nil {
-			return nil, err
+			// APIs like Bitbucket's omit the next page URL in the last page, rather than setting it to null
+			p.Logger.V(1).Info("no next page found in the page. assuming it is the last page", "path", nextpagePath, "error", err.Error())
+			break
 		}
 
 		url = nextUrl
 	}
 
+	// A page can lack valid versions, like the one of only floating tags or prereleases, so it is checked across the pages.
+	// No objects at all is an empty source rather than an error, so that it can be retried with Spec.RetryOnEmpty
+	if pp.objectPath != "" && pp.versionPath != "" && pp.metaKey != "" && len(releases) == 0 && items > 0 {
+		return nil, noValidVersionsError(items, pp.versionPath, pp.objectPath)
+	}
+
+	// Each page is sorted on its own, and versions interleave across pages, like `1.13.1` in the first page and `1.12.9` in the next
+	p.sortReleases(releases)
+
 	return releases, nil
 }
 
-func (p *Tracker) extractObjects(tmp interface{}, objPath, verPath, metaKey string) ([]*Release, error) {
+// noValidVersionsError is returned when none of the objects has a valid version, which matches ErrNoReleases
+func noValidVersionsError(items int, verPath, objPath string) error {
+	return fmt.Errorf("no valid versions extracted out of %d items at path %q under array at %q: %w", items, verPath, objPath, ErrNoReleases)
+}
+
+// extractObjects returns the releases of the objects in the page, along with the number of the objects.
+// The releases are empty when none of the objects has a valid version.
+func (p *Tracker) extractObjects(tmp interface{}, pp *httpJsonPathProvider) ([]*Release, int, error) {
+	objPath, verPath, publishedAtPath, descriptionPath, metaKey := pp.objectPath, pp.versionPath, pp.publishedAtPath, pp.descriptionPath, pp.metaKey
+
 	v, err := maputil.RecursivelyCastKeysToStrings(tmp)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	got, err := jsonpath.Get(objPath, v)
+	got, err := evalJSONPath(objPath, v)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	// The whole document is a single object, like GitHub's latest release
+	if obj, ok := got.(map[string]interface{}); ok && objPath == "$" {
+		got = []interface{}{obj}
 	}
 
 	var rs []*Release
@@ -504,18 +1564,34 @@ func (p *Tracker) extractObjects(tmp interface{}, objPath, verPath, metaKey stri
 		ary = typed
 
 		for _, obj := range typed {
-			raw, err := jsonpath.Get(verPath, obj)
+			if pp.skipObject != nil && pp.skipObject(obj) {
+				continue
+			}
+
+			raw, err := evalJSONPath(verPath, obj)
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
 
 			s, ok := raw.(string)
 			if !ok {
-				return nil, fmt.Errorf("unexpected type of value: want string, got %T, value is %v", raw, raw)
+				return nil, 0, fmt.Errorf("unexpected type of value: want string, got %T, value is %v", raw, raw)
+			}
+
+			if !p.matchesFilter(s) {
+				continue
+			}
+
+			s, ok = p.captureVersion(s)
+			if !ok || p.isFloatingTag(s) {
+				continue
 			}
 
 			v, err := p.parseVersion(s)
 			if err != nil {
+				if !p.skipInvalidVersions() {
+					return nil, 0, fmt.Errorf("parsing version at %q: %q: %v", verPath, s, err)
+				}
 				p.Logger.Info("Ignoring error: parsing semver", "error", err.Error(), "value", s, "jsonPath", verPath)
 				continue
 			}
@@ -524,34 +1600,42 @@ func (p *Tracker) extractObjects(tmp interface{}, objPath, verPath, metaKey stri
 				metaKey: obj,
 			}
 
+			var publishedAt time.Time
+
+			if publishedAtPath != "" {
+				if raw, err := evalJSONPath(publishedAtPath, obj); err == nil {
+					if ts, ok := raw.(string); ok && ts != "" {
+						publishedAt, err = time.Parse(time.RFC3339, ts)
+						if err != nil {
+							p.Logger.Info("Ignoring error: parsing the publish date", "error", err.Error(), "value", ts, "version", s)
+						}
+					}
+				}
+			}
+
+			var description string
+
+			if descriptionPath != "" {
+				if raw, err := evalJSONPath(descriptionPath, obj); err == nil {
+					description, _ = raw.(string)
+				}
+			}
+
 			rs = append(rs, &Release{
-				Semver:  v,
-				Version: strings.TrimPrefix(s, "v"),
-				Meta:    meta,
+				Semver:      v,
+				Version:     p.releaseVersion(s),
+				Description: description,
+				PublishedAt: publishedAt,
+				Meta:        meta,
 			})
 		}
 	default:
-		return nil, fmt.Errorf("extracting json array at path %q: invalid type of value, %T, found", objPath, typed)
-	}
-
-	if len(rs) == 0 {
-		return nil, fmt.Errorf("no valid versions extracted out of %d items at path %q under array at %q", len(ary), verPath, objPath)
+		return nil, 0, fmt.Errorf("extracting json array at path %q: invalid type of value, %T, found", objPath, typed)
 	}
 
-	sort.Slice(rs, func(i, j int) bool {
-		return rs[i].Semver.LessThan(rs[j].Semver)
-	})
-
-	return rs, nil
-}
-
-func (p *Tracker) extractVersions(tmp interface{}, jpath string) ([]*Release, error) {
-	vs, err := p.extractVersionStrings(tmp, jpath)
-	if err != nil {
-		return nil, err
-	}
+	p.sortReleases(rs)
 
-	return p.versionsToReleases(vs)
+	return rs, len(ary), nil
 }
 
 func (p *Tracker) extractString(tmp interface{}, path string) (string, error) {
@@ -560,7 +1644,7 @@ func (p *Tracker) extractString(tmp interface{}, path string) (string, error) {
 		return "", err
 	}
 
-	got, err := jsonpath.Get(path, v)
+	got, err := evalJSONPath(path, v)
 	if err != nil {
 		return "", err
 	}
@@ -581,29 +1665,75 @@ func (p *Tracker) versionsToReleases(vs []string) ([]*Release, error) {
 	return rs, nil
 }
 
+// versionsQuery is the query to extract versions from a document, which is either a JSONPath expression or a jq query.
+// jq takes precedence when both are set.
+type versionsQuery struct {
+	jsonpath string
+	jq       string
+}
+
+func (p *Tracker) queryVersionStrings(tmp interface{}, q versionsQuery) ([]string, error) {
+	if q.jq == "" {
+		return p.extractVersionStrings(tmp, q.jsonpath)
+	}
+
+	v, err := maputil.RecursivelyCastKeysToStrings(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	got, err := evalJQ(q.jq, v)
+	if err != nil {
+		p.Logger.V(2).Info("evaluating jq failed", "jq", q.jq, "document", v)
+		return nil, err
+	}
+
+	vs, err := versionStrings(got, "jq", q.jq, v)
+	if err != nil {
+		p.Logger.V(2).Info("extracting versions failed", "jq", q.jq, "result", got, "document", v)
+	}
+
+	return vs, err
+}
+
 func (p *Tracker) extractVersionStrings(tmp interface{}, jpath string) ([]string, error) {
 	v, err := maputil.RecursivelyCastKeysToStrings(tmp)
 	if err != nil {
 		return nil, err
 	}
 
-	got, err := jsonpath.Get(jpath, v)
+	got, err := evalJSONPath(jpath, v)
 	if err != nil {
+		p.Logger.V(2).Info("evaluating jsonpath failed", "jsonpath", jpath, "document", v)
 		return nil, err
 	}
 
+	vs, err := versionStrings(got, "jsonpath", jpath, v)
+	if err != nil {
+		p.Logger.V(2).Info("extracting versions failed", "jsonpath", jpath, "result", got, "document", v)
+	}
+
+	return vs, err
+}
+
+// versionStrings returns the versions in the result of the query, which is either a version, an array of versions,
+// or a map whose keys are versions
+func versionStrings(got interface{}, lang, query string, v interface{}) ([]string, error) {
 	raw := []interface{}{}
 	switch typed := got.(type) {
 	case []interface{}:
 		raw = typed
 	case map[string]interface{}:
 		raw = append(raw, typed)
+	case string:
+		// A single version, like the one found in a document of a multi-document YAML
+		raw = append(raw, typed)
 	default:
-		return nil, fmt.Errorf("unexpected type of result from jsonpath: \"%s\": %v", jpath, typed)
+		return nil, fmt.Errorf("unexpected type of result from %s: \"%s\": %v", lang, query, typed)
 	}
 
 	if len(raw) == 0 {
-		return nil, fmt.Errorf("jsonpath: \"%s\": returned nothing: %v", jpath, v)
+		return nil, fmt.Errorf("%s: \"%s\": returned nothing: %v", lang, query, v)
 	}
 
 	vs := []string{}
@@ -620,7 +1750,7 @@ func (p *Tracker) extractVersionStrings(tmp interface{}, jpath string) ([]string
 		case string:
 			vs = append(vs, typed)
 		default:
-			return nil, fmt.Errorf("jsonpath: unexpected type of result: %T=%v", typed, typed)
+			return nil, fmt.Errorf("%s: unexpected type of result: %T=%v", lang, typed, typed)
 		}
 	}
 
@@ -657,80 +1787,340 @@ func nonSemverWorkaround(s string) string {
 	return s
 }
 
+// trimVersion removes TrimVersionPrefix and TrimVersionSuffix from the string.
+// It reports whether either of them was removed.
+func (p *Tracker) trimVersion(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+
+	trimmed := s
+	if p.Spec.TrimVersionPrefix != "" {
+		trimmed = strings.TrimPrefix(trimmed, p.Spec.TrimVersionPrefix)
+	}
+	if p.Spec.TrimVersionSuffix != "" {
+		trimmed = strings.TrimSuffix(trimmed, p.Spec.TrimVersionSuffix)
+	}
+
+	return trimmed, trimmed != s
+}
+
+// releaseVersion returns the Release.Version for the string the semver was parsed from.
+// The original tag is kept as-is when TrimVersionPrefix or TrimVersionSuffix was removed from it,
+// so that callers can still check out the real tag.
+func (p *Tracker) releaseVersion(s string) string {
+	if _, trimmed := p.trimVersion(s); trimmed {
+		return strings.TrimSpace(s)
+	}
+
+	return strings.TrimPrefix(s, "v")
+}
+
 func (p *Tracker) parseVersion(s string) (*semver.Version, error) {
-	fixedS := nonSemverWorkaround(strings.TrimSpace(s))
+	trimmed, _ := p.trimVersion(s)
+
+	fixedS := nonSemverWorkaround(trimmed)
 
 	return semver.NewVersion(fixedS)
 }
 
+// DefaultFloatingTags are the tags that are moved across releases rather than naming a specific version,
+// which are ignored by default.
+var DefaultFloatingTags = []string{"latest", "main", "master", "edge", "stable", "nightly"}
+
+// matchesFilter reports whether the string obtained from the source matches Filter, if any
+func (p *Tracker) matchesFilter(s string) bool {
+	if p.filter == nil || p.filter.MatchString(s) {
+		return true
+	}
+
+	p.Logger.V(1).Info("ignoring string not matching filter", "value", s, "filter", p.filter.String())
+
+	return false
+}
+
+// captureVersion extracts the version out of the string with VersionCapture.
+// It returns false when VersionCapture is set but didn't match.
+func (p *Tracker) captureVersion(s string) (string, bool) {
+	if p.versionCapture == nil {
+		return s, true
+	}
+
+	m := p.versionCapture.FindStringSubmatch(s)
+	if m == nil {
+		p.Logger.V(1).Info("ignoring string not matching versionCapture", "value", s, "versionCapture", p.versionCapture.String())
+		return "", false
+	}
+
+	if len(m) > 1 {
+		return m[1], true
+	}
+
+	return m[0], true
+}
+
+func (p *Tracker) isFloatingTag(s string) bool {
+	if p.Spec.IgnoreFloatingTags != nil && !*p.Spec.IgnoreFloatingTags {
+		return false
+	}
+
+	tags := p.Spec.FloatingTags
+	if tags == nil {
+		tags = DefaultFloatingTags
+	}
+
+	s = strings.TrimSpace(s)
+
+	for _, t := range tags {
+		if strings.EqualFold(s, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *Tracker) versionStringsToReleases(vs []string) ([]*Release, error) {
 	rs := []*Release{}
 	for i, s := range vs {
+		if !p.matchesFilter(s) {
+			continue
+		}
+
+		s, ok := p.captureVersion(s)
+		if !ok || p.isFloatingTag(s) {
+			continue
+		}
+
 		v, err := p.parseVersion(s)
 		if err != nil {
 			e := fmt.Errorf("parsing version: index %d: %q: %v", i, s, err)
+			if !p.skipInvalidVersions() {
+				// The whole list helps telling a malformed upstream data from a misconfigured query
+				p.Logger.V(2).Info("parsing versions failed", "versions", vs, "error", e.Error())
+				return nil, e
+			}
 			p.Logger.V(1).Info("ignoring error", "err", e)
 		}
 
 		if v != nil {
 			rs = append(rs, &Release{
 				Semver:  v,
-				Version: strings.TrimPrefix(s, "v"),
+				Version: p.releaseVersion(s),
 			})
 		}
 	}
 
-	sort.Slice(rs, func(i, j int) bool {
-		return rs[i].Semver.LessThan(rs[j].Semver)
-	})
+	p.sortReleases(rs)
 
 	return rs, nil
 }
 
+// customProviderKind is the kind of the provider set via the Provider option
+const customProviderKind = "provider"
+
+// ProviderKind returns the kind of the versions source, which is the name of the source field in the config
+// like `githubReleases`, `dockerImageTags`, `gitTags` or `jsonPath`, or `provider` for the one set via Provider.
+// It is the kind of the provider returned by GetProvider.
+func (p *Tracker) ProviderKind() string {
+	sources := p.Spec.VersionsFrom.configuredSources()
+	if len(sources) == 0 {
+		if p.provider != nil {
+			return customProviderKind
+		}
+		return ""
+	}
+
+	return sources[0]
+}
+
+// sourceURLProvider is implemented by the providers that fetch releases from a single upstream URL
+type sourceURLProvider interface {
+	sourceURL() string
+}
+
+// SourceURL returns the upstream URL the releases are fetched from, like
+// `https://api.github.com/repos/mumoshu/variant/releases` for githubReleases, for displaying and logging.
+// The URL is the one before URLRewriter is applied, and lacks pagination and query parameters.
+// It returns an empty string for sources that don't have a single URL, like exec and the KV stores.
+func (p *Tracker) SourceURL() string {
+	return p.sourceURLOf(p.Spec.VersionsFrom)
+}
+
+func (p *Tracker) sourceURLOf(vf VersionsFrom) string {
+	_, pp, err := p.resolveBaseProvider(vf)
+	if err != nil {
+		return ""
+	}
+
+	if u, ok := pp.(sourceURLProvider); ok {
+		return u.sourceURL()
+	}
+
+	return ""
+}
+
 func (p *Tracker) GetProvider() (ReleaseProvider, error) {
-	versionsFrom := p.Spec.VersionsFrom
+	_, pp, err := p.resolveProvider(p.Spec.VersionsFrom)
+
+	return pp, err
+}
 
-	if versionsFrom.JSONPath.Source != "" {
-		return newGetterProvider(versionsFrom.JSONPath, p), nil
-	} else if versionsFrom.Exec.Command != "" {
-		return newExecProvider(versionsFrom.Exec.Command, versionsFrom.Exec.Args, p), nil
-	} else if versionsFrom.DockerImageTags.Source != "" {
-		return newDockerHubImageTagsProvider(versionsFrom.DockerImageTags, p), nil
-	} else if versionsFrom.GitTags.Source != "" {
-		cmd := fmt.Sprintf("git ls-remote --tags git://%s.git | grep -v { | awk '{ print $2 }' | cut -d'/' -f 3", versionsFrom.GitTags.Source)
-		return newShellProvider(cmd, p), nil
-	} else if versionsFrom.GitHubTags.Source != "" {
-		return newGitHubTagsProvider(versionsFrom.GitHubTags, p), nil
-	} else if versionsFrom.GitHubReleases.Source != "" {
-		return newGitHubReleasesProvider(versionsFrom.GitHubReleases, p), nil
+// resolveProvider returns the provider for the versions source along with the kind of it,
+// which is the name of the source field in the config like `githubReleases`.
+// The provider is wrapped by the middlewares enabled via ProviderMiddlewares.
+func (p *Tracker) resolveProvider(versionsFrom VersionsFrom) (string, ReleaseProvider, error) {
+	kind, pp, err := p.resolveBaseProvider(versionsFrom)
+	if err != nil {
+		return "", nil, err
 	}
-	return nil, fmt.Errorf("no versions provider specified")
+
+	return kind, composeMiddlewares(pp, p.middlewares), nil
+}
+
+func (p *Tracker) resolveBaseProvider(versionsFrom VersionsFrom) (string, ReleaseProvider, error) {
+	sources := versionsFrom.configuredSources()
+	if len(sources) == 0 {
+		if p.provider != nil {
+			return customProviderKind, p.provider, nil
+		}
+		return "", nil, fmt.Errorf("no versions provider specified")
+	}
+
+	kind := sources[0]
+
+	switch kind {
+	case "jsonPath":
+		return kind, newGetterProvider(versionsFrom.JSONPath, p), nil
+	case "exec":
+		return kind, newExecProvider(versionsFrom.Exec, p), nil
+	case "dockerImageTags":
+		return kind, newDockerHubImageTagsProvider(versionsFrom.DockerImageTags, p), nil
+	case "containerImageTags":
+		return kind, newContainerImageTagsProvider(versionsFrom.ContainerImageTags, p), nil
+	case "gitTags":
+		return kind, newGitTagsProvider(versionsFrom.GitTags, p), nil
+	case "helmChart":
+		return kind, newHelmChartProvider(versionsFrom.HelmChart, p), nil
+	case "helmOCI":
+		return kind, newHelmOCIProvider(versionsFrom.HelmOCI, p), nil
+	case "goProxy":
+		return kind, newGoProxyProvider(versionsFrom.GoProxy, p), nil
+	case "npmPackage":
+		return kind, newNpmPackageProvider(versionsFrom.NpmPackage, p), nil
+	case "pypiPackage":
+		return kind, newPyPIProvider(versionsFrom.PyPIPackage, p), nil
+	case "mavenArtifact":
+		return kind, newMavenProvider(versionsFrom.MavenArtifact, p), nil
+	case "scoop":
+		return kind, newScoopProvider(versionsFrom.Scoop, p), nil
+	case "gitFile":
+		return kind, newGitFileProvider(versionsFrom.GitFile, p), nil
+	case "githubTags":
+		return kind, newGitHubTagsProvider(versionsFrom.GitHubTags, p), nil
+	case "bitbucketTags":
+		return kind, newBitbucketTagsProvider(versionsFrom.BitbucketTags, p), nil
+	case "gitlabReleases":
+		return kind, newGitLabReleasesProvider(versionsFrom.GitLabReleases, p), nil
+	case "gitlabTags":
+		return kind, newGitLabTagsProvider(versionsFrom.GitLabTags, p), nil
+	case "githubReleases":
+		return kind, newGitHubReleasesProvider(versionsFrom.GitHubReleases, p), nil
+	case "httpJSONPath":
+		return kind, newHTTPJSONPathProvider(versionsFrom.HTTPJSONPath, p), nil
+	case "consulKV":
+		return kind, newConsulKVProvider(versionsFrom.ConsulKV, p), nil
+	case "etcdKV":
+		return kind, newEtcdKVProvider(versionsFrom.EtcdKV, p), nil
+	case "s3":
+		return kind, newS3Provider(versionsFrom.S3, p), nil
+	case "file":
+		return kind, newFileProvider(versionsFrom.File, p), nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported versions source: %s", kind)
 }
 
 func (p *Tracker) GetReleases() ([]*Release, error) {
-	pp, err := p.GetProvider()
+	return p.GetReleasesContext(context.Background())
+}
+
+// GetReleasesContext is GetReleases that gives up fetching releases once the context is done.
+// Set Spec.PartialResultsOnDeadline to obtain the releases fetched before the deadline, instead of the error.
+func (p *Tracker) GetReleasesContext(ctx context.Context) ([]*Release, error) {
+	return p.getReleases(ctx, false)
+}
+
+// getReleases is GetReleasesContext that keeps prereleases regardless of Spec.ExcludePrereleases when keepPrereleases is true
+func (p *Tracker) getReleases(ctx context.Context, keepPrereleases bool) ([]*Release, error) {
+	report := &FetchReport{}
+	defer p.setLastFetchReport(report)
+
+	kind, all, err := p.fetchFallingBack(ctx, report)
 	if err != nil {
 		return nil, err
 	}
 
-	all, err := pp.All()
+	rs, err := p.applyDuplicatePolicy(p.filterReleases(all))
 	if err != nil {
 		return nil, err
 	}
 
-	if p.Spec.VersionsFrom.ValidVersionPattern == nil {
-		return all, err
+	// Counted before excluding prereleases so that the check doesn't depend on the constraint
+	if len(rs) < p.Spec.MinReleases {
+		return nil, fmt.Errorf("%s returned %d valid releases, but at least %d are required: check the versions source for misconfiguration", kind, len(rs), p.Spec.MinReleases)
+	}
+
+	if p.Spec.ExcludePrereleases && !keepPrereleases {
+		var stable []*Release
+
+		for _, r := range rs {
+			if r.Semver.Prerelease() == "" {
+				stable = append(stable, r)
+			}
+		}
+
+		rs = stable
 	}
 
+	// Re-sort as neither the middlewares nor the custom provider are obliged to sort with the comparator
+	p.sortReleases(rs)
+
+	return rs, nil
+}
+
+var prereleaseInConstraint = regexp.MustCompile(`\d-[0-9A-Za-z-]`)
+
+// namesPrerelease reports whether the constraint names a prerelease like `>= 1.2.0-rc.1`,
+// which is the intent to track prereleases even when Spec.ExcludePrereleases is set
+func namesPrerelease(constraint string) bool {
+	return prereleaseInConstraint.MatchString(constraint)
+}
+
+func (p *Tracker) filterReleases(all []*Release) []*Release {
+	validVersionPattern := p.Spec.VersionsFrom.ValidVersionPattern
+
 	var filtered []*Release
 
 	for i := range all {
 		r := all[i]
 
-		if p.Spec.VersionsFrom.ValidVersionPattern.MatchString(r.Version) {
-			filtered = append(filtered, r)
+		if validVersionPattern != nil && !validVersionPattern.MatchString(r.Version) {
+			continue
+		}
+
+		if p.Spec.BuildMetadata != "" && r.Semver.Metadata() != p.Spec.BuildMetadata {
+			continue
+		}
+
+		if containsVersion(p.excludeVersions, r.Semver) {
+			continue
 		}
+
+		if len(p.includeVersions) > 0 && !containsVersion(p.includeVersions, r.Semver) {
+			continue
+		}
+
+		filtered = append(filtered, r)
 	}
 
-	return filtered, nil
+	return filtered
 }
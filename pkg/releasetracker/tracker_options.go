@@ -1,10 +1,15 @@
 package releasetracker
 
 import (
+	"fmt"
+	"github.com/Masterminds/semver"
 	"github.com/go-logr/logr"
 	"github.com/twpayne/go-vfs"
 	"github.com/variantdev/mod/pkg/cmdsite"
 	"github.com/variantdev/mod/pkg/vhttpget"
+	"net/http"
+	"net/url"
+	"time"
 )
 
 func Logger(logger logr.Logger) Option {
@@ -72,6 +77,134 @@ func (o *httpGetterOption) SetOption(r *Tracker) error {
 	return nil
 }
 
+// HTTPTimeout sets the timeout of each HTTP request made by the GitHub, GitLab, Docker Hub, container registry
+// and HTTP JSONPath providers. Defaults to vhttpget.DefaultTimeout.
+// It has no effect when HTTPClient or HttpGetter is set, nor on the exec and jsonPath providers,
+// as commands and go-getter don't use the HTTP getter.
+func HTTPTimeout(d time.Duration) Option {
+	return &httpTimeoutOption{d: d}
+}
+
+type httpTimeoutOption struct {
+	d time.Duration
+}
+
+func (o *httpTimeoutOption) SetOption(r *Tracker) error {
+	if o.d <= 0 {
+		return fmt.Errorf("http timeout must be positive: got %v", o.d)
+	}
+	r.httpTimeout = o.d
+	return nil
+}
+
+// HTTPClient sets the client used for the requests made by the HTTP based providers, like githubReleases and
+// containerImageTags, for a corporate proxy, a custom CA bundle, or mutual TLS. The timeout of the client is used
+// instead of HTTPTimeout. It has no effect when HttpGetter is set.
+func HTTPClient(c *http.Client) Option {
+	return &httpClientOption{c: c}
+}
+
+type httpClientOption struct {
+	c *http.Client
+}
+
+func (o *httpClientOption) SetOption(r *Tracker) error {
+	r.httpClient = o.c
+	return nil
+}
+
+// Proxy sends the requests made by the HTTP based providers via the proxy, like `http://proxy.example.com:3128`,
+// regardless of the envvars. Without it, the proxy is read from `HTTPS_PROXY` for https URLs and `HTTP_PROXY` for
+// http URLs, or their lowercase versions, except for the hosts in `NO_PROXY`. It can't be used along with HTTPClient,
+// and has no effect when HttpGetter is set.
+func Proxy(proxyURL string) Option {
+	return &proxyOption{u: proxyURL}
+}
+
+type proxyOption struct {
+	u string
+}
+
+func (o *proxyOption) SetOption(r *Tracker) error {
+	u, err := url.Parse(o.u)
+	if err != nil {
+		return fmt.Errorf("parsing proxy url %q: %w", o.u, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("proxy url must have the scheme and the host, like http://proxy.example.com:3128: got %q", o.u)
+	}
+	r.httpProxy = u
+	return nil
+}
+
+// UserAgent sets the `User-Agent` header of the requests made by the HTTP based providers, for the APIs identifying
+// or rejecting the requests by it. Defaults to vhttpget.DefaultUserAgent. The one in the headers of the source takes precedence.
+func UserAgent(ua string) Option {
+	return &userAgentOption{ua: ua}
+}
+
+type userAgentOption struct {
+	ua string
+}
+
+func (o *userAgentOption) SetOption(r *Tracker) error {
+	r.userAgent = o.ua
+	return nil
+}
+
+// HTTPRetries retries the requests made by the HTTP JSONPath based providers, like githubReleases and dockerImageTags,
+// up to `count` times on network errors and 5xx and 429 responses. The wait before the first retry is `base`,
+// which doubles on each retry. 4xx responses are never retried.
+func HTTPRetries(count int, base time.Duration) Option {
+	return &httpRetriesOption{count: count, base: base}
+}
+
+type httpRetriesOption struct {
+	count int
+	base  time.Duration
+}
+
+func (o *httpRetriesOption) SetOption(r *Tracker) error {
+	if o.count < 0 {
+		return fmt.Errorf("http retries must not be negative: got %d", o.count)
+	}
+	r.httpRetries = o.count
+	r.httpRetryBackoff = o.base
+	return nil
+}
+
+// CacheTTL caches the HTTP responses fetched by the HTTP JSONPath based providers, like githubReleases and dockerImageTags,
+// on disk under the cache directory for `ttl`. Use Tracker.InvalidateCache to force a refetch.
+// A non-positive ttl disables the cache, which is the default.
+func CacheTTL(ttl time.Duration) Option {
+	return &cacheTTLOption{ttl: ttl}
+}
+
+type cacheTTLOption struct {
+	ttl time.Duration
+}
+
+func (o *cacheTTLOption) SetOption(r *Tracker) error {
+	r.httpCacheTTL = o.ttl
+	return nil
+}
+
+// VersionComparator replaces the semver precedence used to order the releases and to pick the latest one,
+// with less reporting whether the version a is older than b. Use BuildMetadataLess for calendar versions whose
+// build metadata matters for ordering. Use the Descending list option of Releases for the newest release first.
+func VersionComparator(less func(a, b *semver.Version) bool) Option {
+	return &versionComparatorOption{less: less}
+}
+
+type versionComparatorOption struct {
+	less func(a, b *semver.Version) bool
+}
+
+func (o *versionComparatorOption) SetOption(r *Tracker) error {
+	r.versionLess = o.less
+	return nil
+}
+
 func Commander(rc cmdsite.RunCommand) Option {
 	return &commanderOption{rc: rc}
 }
@@ -84,3 +217,114 @@ func (o *commanderOption) SetOption(r *Tracker) error {
 	r.cmdSite.RunCmd = o.rc
 	return nil
 }
+
+// KVClient overrides the client used by the consulKV and etcdKV providers to read keys
+func KVClient(kv KVReader) Option {
+	return &kvClientOption{kv: kv}
+}
+
+type kvClientOption struct {
+	kv KVReader
+}
+
+func (o *kvClientOption) SetOption(r *Tracker) error {
+	r.kvReader = o.kv
+	return nil
+}
+
+// S3Client overrides the client used by the s3 provider to list the objects in the bucket
+func S3Client(l S3Lister) Option {
+	return &s3ClientOption{l: l}
+}
+
+type s3ClientOption struct {
+	l S3Lister
+}
+
+func (o *s3ClientOption) SetOption(r *Tracker) error {
+	r.s3Lister = o.l
+	return nil
+}
+
+// Provider replaces the versions source with the provider, like a StaticProvider in tests. The releases returned by the
+// provider are sorted by the tracker, unless Spec.PreserveOrder is set.
+// The spec must not configure a versions source, but can still configure fallbacks to be tried when the provider fails.
+func Provider(pp ReleaseProvider) Option {
+	return &providerOption{pp: pp}
+}
+
+type providerOption struct {
+	pp ReleaseProvider
+}
+
+func (o *providerOption) SetOption(r *Tracker) error {
+	r.provider = o.pp
+	return nil
+}
+
+// URLRewriter sets the function to rewrite the URL of every HTTP request made by providers, including the ones to
+// container registries. Sources fetched via go-getter or git, like jsonPath and gitTags, are not rewritten.
+// This is handy for routing requests through internal gateways, e.g. `api.github.com` to `gateway.internal/github`.
+//
+// When not set, rewrites are read from the VARIANT_MOD_URL_REWRITES envvar, if any.
+func URLRewriter(f func(string) string) Option {
+	return &urlRewriterOption{f: f}
+}
+
+type urlRewriterOption struct {
+	f func(string) string
+}
+
+func (o *urlRewriterOption) SetOption(r *Tracker) error {
+	r.urlRewriter = o.f
+	return nil
+}
+
+// ProviderMiddlewares wraps the provider of the versions source with the middlewares.
+// The first middleware becomes the outermost.
+func ProviderMiddlewares(mws ...Middleware) Option {
+	return &middlewaresOption{mws: mws}
+}
+
+type middlewaresOption struct {
+	mws []Middleware
+}
+
+func (o *middlewaresOption) SetOption(r *Tracker) error {
+	r.middlewares = append(r.middlewares, o.mws...)
+	return nil
+}
+
+// MaxConcurrentPolls caps the number of polls that can be in flight at once across all the watchers
+// started via Watch on the tracker. 0 means unlimited.
+func MaxConcurrentPolls(n int) Option {
+	return &maxConcurrentPollsOption{n: n}
+}
+
+type maxConcurrentPollsOption struct {
+	n int
+}
+
+func (o *maxConcurrentPollsOption) SetOption(r *Tracker) error {
+	if o.n > 0 {
+		r.pollLimiter = make(chan struct{}, o.n)
+	} else {
+		r.pollLimiter = nil
+	}
+	return nil
+}
+
+// Secrets sets the resolver for the secret references in the spec, like `vault:secret/github#token` in the token of
+// a versions source. `env:` and `file:` references are always resolved by the tracker itself.
+func Secrets(r SecretResolver) Option {
+	return &secretsOption{r: r}
+}
+
+type secretsOption struct {
+	r SecretResolver
+}
+
+func (o *secretsOption) SetOption(r *Tracker) error {
+	r.secretResolver = o.r
+	return nil
+}
@@ -1,11 +1,21 @@
 package releasetracker
 
 import (
+	"errors"
+	"fmt"
 	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twpayne/go-vfs/vfst"
 	"github.com/variantdev/mod/pkg/cmdsite"
 	"github.com/variantdev/mod/pkg/vhttpget"
 	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetLatest(t *testing.T) {
@@ -98,9 +108,7 @@ func TestProvider_Exec(t *testing.T) {
 func TestProvider_GitTags(t *testing.T) {
 	input := `releaseChannel:
   versionsFrom:
-    # This basically runs "git ls-remote --tags git://github.com/mumoshu/variant.git" to fetch available versions
-    # Examples can be obtained by running:
-    #   git ls-remote --tags git://github.com/mumoshu/variant.git | grep -v { | awk '{ print $2 }' | cut -d'/' -f 3
+    # This basically runs "git ls-remote --tags https://github.com/mumoshu/variant.git" to fetch available versions
     gitTags:
       source: github.com/mumoshu/variant
 `
@@ -110,29 +118,30 @@ func TestProvider_GitTags(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expectedStdout := `v0.21.2
-v0.22.0
-v0.23.0
-v0.24.0
-v0.24.1
-v0.25.0
-v0.25.1
-v0.25.2
-v0.26.0
-v0.27.0
-v0.27.1
-v0.27.2
-v0.27.3
-v0.27.4
-v0.27.5
-v0.28.0
-v0.29.0
-v0.30.0
-v0.31.0
-v0.31.1
+	expectedStdout := `83a7e7ad4d1faea246da66abb7e4d198db7f9c1f	refs/tags/v0.21.2
+e962fdb9b1e804611c9a97f1799603a8e36979a1	refs/tags/v0.22.0
+1eb0008a5e2f85897de67b80a939e12495e1e2dc	refs/tags/v0.23.0
+cbdbe566564c323032c02c1a838358a314af63b4	refs/tags/v0.24.0
+9e8675140b5bc23da9630662c6a65aedcda20591	refs/tags/v0.24.1
+38f891b56474ebe962e3804fbd29e7f4db8d220b	refs/tags/v0.25.0
+268da326c02a9cdc9a1409ed12b7f87bda1ce561	refs/tags/v0.25.1
+b90254323e07aaa6740494c8cbd3c5dbff6679f0	refs/tags/v0.25.2
+940d56805df96a0d2f8eb8a3f84b91ee74a47cd0	refs/tags/v0.26.0
+04c6f8724f2feafa7e298fdd18934fca052fb027	refs/tags/v0.27.0
+016153e14e39fd6bf74a037d5b0c7f4e8aead45a	refs/tags/v0.27.1
+d9394695219d9c09842dd3beecbdbda8a683837e	refs/tags/v0.27.2
+1e1009b49655d8b55558d1b558b6c29d608851ab	refs/tags/v0.27.3
+da39945fca230e316428e0ccdb5a7654fca41dde	refs/tags/v0.27.4
+b8b2e772a02d1a9ed5c0a378c7f0477adc33a318	refs/tags/v0.27.5
+bf70f1efe6197e59cd00657f0d186b30728c71e7	refs/tags/v0.28.0
+e340b198f43eaf4ab3509c31335b01a8e85ba0c7	refs/tags/v0.29.0
+9b22788d0151d52cccd1fe14b63eaa760e4211f1	refs/tags/v0.30.0
+9f236259294ab9fd27654e203eec1657f50fb399	refs/tags/v0.31.0
+21f7c47037a07d9474ab06055ed64f336eb67a94	refs/tags/v0.31.1
+da7b20d9f041309d02023b225bc7a24095529535	refs/tags/v0.31.1^{}
 `
 
-	expectedInput := cmdsite.NewInput("sh", []string{"-c", "git ls-remote --tags git://github.com/mumoshu/variant.git | grep -v { | awk '{ print $2 }' | cut -d'/' -f 3"}, map[string]string{})
+	expectedInput := cmdsite.NewInput("git", []string{"ls-remote", "--tags", "https://github.com/mumoshu/variant.git"}, map[string]string{})
 	cmdr := cmdsite.NewTester(map[cmdsite.CommandInput]cmdsite.CommandOutput{
 		expectedInput: {Stdout: expectedStdout},
 	})
@@ -443,6 +452,20 @@ func TestProvider_GitHubReleases(t *testing.T) {
 	if latest.Version != expected {
 		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
 	}
+
+	expectedDescription := "## Changelog\n\nc78b3b7 Improvement #97: Inverse inherited params processing in tasks (#96)\n3080240 Remove unused viper variable (#98)\n\n"
+	if latest.Description != expectedDescription {
+		t.Errorf("unexpected description: expected=%q, got=%q", expectedDescription, latest.Description)
+	}
+
+	assets, err := stable.Assets(latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(assets) != 5 || assets[4].Name != "variant_0.31.1_linux_amd64.tar.gz" {
+		t.Errorf("unexpected assets: %+v", assets)
+	}
 }
 
 func TestProvider_DockerRegistryImageTags(t *testing.T) {
@@ -477,3 +500,2744 @@ func TestProvider_DockerRegistryImageTags(t *testing.T) {
 		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
 	}
 }
+
+func TestProvider_DockerRegistryImageTags_Pagination(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    dockerImageTags:
+      source: library/golang
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page_size=1000"}:        `{"next": "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page=2&page_size=1000", "results": [{"name": "1.13.1"}, {"name": "1.13.0"}]}`,
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page=2&page_size=1000"}: `{"next": null, "results": [{"name": "1.14.0"}, {"name": "1.12.9"}]}`,
+	}
+	httpGetter := vhttpget.NewTester(gets)
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(httpGetter))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1.14.0"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+
+	conf.ReleaseChannel.VersionsFrom.DockerImageTags.MaxPages = 1
+
+	capped, err := New(conf.ReleaseChannel, HttpGetter(httpGetter))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err = capped.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = "1.13.1"
+	if latest.Version != expected {
+		t.Errorf("unexpected version with maxPages: expected=%v, got=%v", expected, latest.Version)
+	}
+}
+
+func TestProvider_DockerRegistryImageTags_PagesInterleaving(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page_size=1000"}:        `{"next": "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page=2&page_size=1000", "results": [{"name": "1.13.1"}, {"name": "1.13.0"}]}`,
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/library/golang/tags/?page=2&page_size=1000"}: `{"next": null, "results": [{"name": "1.14.0"}, {"name": "1.12.9"}]}`,
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{DockerImageTags: DockerImageTags{Source: "library/golang"}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.12.9", "1.13.0", "1.13.1", "1.14.0"}, vs); d != "" {
+		t.Errorf("unexpected releases: %s", d)
+	}
+
+	desc, err := tracker.Releases(Descending())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if desc[0].Version != "1.14.0" {
+		t.Errorf("unexpected first release in descending order: expected=1.14.0, got=%s", desc[0].Version)
+	}
+}
+
+func TestProvider_DockerRegistryImageTags_PageWithoutVersions(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/myorg/myapp/tags/?page_size=1000"}:        `{"next": "https://registry.hub.docker.com/v2/repositories/myorg/myapp/tags/?page=2&page_size=1000", "results": [{"name": "latest"}, {"name": "sha-abc"}]}`,
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/myorg/myapp/tags/?page=2&page_size=1000"}: `{"next": null, "results": [{"name": "1.1.0"}, {"name": "1.0.0"}]}`,
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{DockerImageTags: DockerImageTags{Source: "myorg/myapp"}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.1.0" {
+		t.Errorf("unexpected version: expected=1.1.0, got=%s", latest.Version)
+	}
+
+	spec.VersionsFrom.DockerImageTags.MaxPages = 1
+
+	capped, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := capped.Latest(""); err == nil || !strings.Contains(err.Error(), "no valid versions extracted out of 2 items") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestProvider_BitbucketTags(t *testing.T) {
+	defer setenv(t, "BITBUCKET_TOKEN", "secret")()
+
+	input := `releaseChannel:
+  versionsFrom:
+    bitbucketTags:
+      workspace: myorg
+      repoSlug: myrepo
+      token: env:BITBUCKET_TOKEN
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	auth := "Authorization: Bearer secret"
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.bitbucket.org/2.0/repositories/myorg/myrepo/refs/tags?pagelen=100", Headers: auth}:        `{"next": "https://api.bitbucket.org/2.0/repositories/myorg/myrepo/refs/tags?page=2&pagelen=100", "values": [{"name": "v1.1.0", "target": {"date": "2021-03-01T12:00:00+00:00"}}, {"name": "v1.0.0"}]}`,
+		vhttpget.TestGetInput{URL: "https://api.bitbucket.org/2.0/repositories/myorg/myrepo/refs/tags?page=2&pagelen=100", Headers: auth}: `{"values": [{"name": "v1.2.0", "target": {"date": "2021-04-01T12:00:00+00:00"}}, {"name": "v0.9.0"}]}`,
+	}
+
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1.2.0"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+
+	publishedAt := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	if !latest.PublishedAt.Equal(publishedAt) {
+		t.Errorf("unexpected publish date: expected=%v, got=%v", publishedAt, latest.PublishedAt)
+	}
+}
+
+type stubKV struct {
+	key    string
+	prefix bool
+	values []string
+}
+
+func (kv *stubKV) Get(key string, prefix bool) ([]string, error) {
+	if key != kv.key || prefix != kv.prefix {
+		return nil, fmt.Errorf("unexpected key: key=%q, prefix=%v", key, prefix)
+	}
+	return kv.values, nil
+}
+
+func TestProvider_ConsulKV(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    consulKV:
+      address: consul.internal:8500
+      key: releases/myapp/
+      prefix: true
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	kv := &stubKV{key: "releases/myapp/", prefix: true, values: []string{"1.0.0", "1.2.0\n", "1.1.0"}}
+	stable, err := New(conf.ReleaseChannel, KVClient(kv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := stable.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1.2.0"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+}
+
+func TestProvider_EtcdKV(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    etcdKV:
+      key: /releases/myapp/approved
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "http://127.0.0.1:2379/v2/keys/releases/myapp/approved"}: `{"action":"get","node":{"key":"/releases/myapp/approved","value":"v0.31.1","modifiedIndex":7,"createdIndex":7}}`,
+	}
+	stable, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := stable.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "0.31.1"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+}
+
+func TestProvider_EtcdKV_Credentials(t *testing.T) {
+	defer setenv(t, "ETCD_PASSWORD", "secret")()
+
+	spec := Spec{VersionsFrom: VersionsFrom{EtcdKV: EtcdKV{
+		Key:      "/releases/myapp/approved",
+		Username: "mod",
+		Password: "env:ETCD_PASSWORD",
+	}}}
+
+	// The credentials must not be in the URL, which is logged
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "http://127.0.0.1:2379/v2/keys/releases/myapp/approved", Headers: "Authorization: Basic bW9kOnNlY3JldA=="}: `{"node":{"key":"/releases/myapp/approved","value":"v0.31.1"}}`,
+	}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.31.1" {
+		t.Errorf("unexpected version: expected=0.31.1, got=%v", latest.Version)
+	}
+}
+
+func TestProvider_URLRewriter(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    githubTags:
+      source: mumoshu/variant
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://gateway.internal/github/repos/mumoshu/variant/tags"}: `[{"name": "v0.34.0"}]`,
+	}
+
+	rewriter := func(u string) string {
+		return strings.Replace(u, "https://api.github.com/", "https://gateway.internal/github/", 1)
+	}
+
+	t.Run("option", func(t *testing.T) {
+		stable, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)), URLRewriter(rewriter))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := stable.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "0.34.0" {
+			t.Errorf("unexpected version: expected=%v, got=%v", "0.34.0", latest.Version)
+		}
+	})
+
+	t.Run("envvar", func(t *testing.T) {
+		defer setenv(t, "VARIANT_MOD_URL_REWRITES", "https://example.com=https://unused.internal,https://api.github.com/=https://gateway.internal/github/")()
+
+		stable, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := stable.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "0.34.0" {
+			t.Errorf("unexpected version: expected=%v, got=%v", "0.34.0", latest.Version)
+		}
+	})
+}
+
+// newFakeExecTracker returns a tracker backed by an exec source that prints the versions
+func newFakeExecTracker(t *testing.T, conf Spec, versions string, opts ...Option) *Tracker {
+	t.Helper()
+
+	conf.VersionsFrom.Exec = Exec{Command: "sh", Args: []string{"-c", "list-versions"}}
+
+	expectedInput := cmdsite.NewInput("sh", []string{"-c", "list-versions"}, map[string]string{})
+	cmdr := cmdsite.NewTester(map[cmdsite.CommandInput]cmdsite.CommandOutput{
+		expectedInput: {Stdout: versions},
+	})
+
+	tracker, err := New(conf, append(opts, Commander(cmdr))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tracker
+}
+
+func TestTracker_BuildMetadata(t *testing.T) {
+	testcases := []struct {
+		metadata string
+		expected string
+	}{
+		{metadata: "prod", expected: "1.2.0+prod"},
+		{metadata: "staging", expected: "1.3.0+staging"},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.metadata, func(t *testing.T) {
+			tracker := newFakeExecTracker(t, Spec{BuildMetadata: tc.metadata}, "1.1.0+prod\n1.2.0+prod\n1.3.0+staging\n1.0.0+prod\n")
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest.Version != tc.expected {
+				t.Errorf("unexpected version: expected=%v, got=%v", tc.expected, latest.Version)
+			}
+		})
+	}
+}
+
+func TestTracker_Fallbacks(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]vhttpget.Response{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: {
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{"X-Ratelimit-Remaining": []string{"0"}},
+			Body:       `{"message": "API rate limit exceeded"}`,
+		},
+		vhttpget.TestGetInput{URL: "https://releases.example.com/empty.json"}:   {Body: `[]`},
+		vhttpget.TestGetInput{URL: "https://releases.example.com/variant.json"}: {Body: `[{"version": "0.36.0"}, {"version": "0.37.0"}]`},
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{
+		GitHubReleases: GitHubReleases{Source: "mumoshu/variant"},
+		Fallbacks: []VersionsFrom{
+			{HTTPJSONPath: HTTPJSONPath{URL: "https://releases.example.com/empty.json", Versions: "$[*].version"}},
+			{HTTPJSONPath: HTTPJSONPath{URL: "https://releases.example.com/variant.json", Versions: "$[*].version"}},
+		},
+	}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewResponseTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.37.0" {
+		t.Errorf("unexpected version: expected=0.37.0, got=%s", latest.Version)
+	}
+
+	var sources []string
+	for _, a := range tracker.LastFetchReport().Attempts {
+		sources = append(sources, a.Source)
+	}
+
+	expectedSources := []string{
+		"https://api.github.com/repos/mumoshu/variant/releases",
+		"https://releases.example.com/empty.json",
+		"https://releases.example.com/variant.json",
+	}
+
+	if d := cmp.Diff(expectedSources, sources); d != "" {
+		t.Errorf("unexpected sources of the attempts: %s", d)
+	}
+
+	spec.VersionsFrom.Fallbacks = spec.VersionsFrom.Fallbacks[:1]
+
+	tracker, err = New(spec, HttpGetter(vhttpget.NewResponseTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tracker.Latest(""); err == nil || errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected the error of the last fallback, got %v", err)
+	}
+}
+
+func TestTracker_Fallbacks_Cache(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://releases.example.com/variant.json"}: `[{"version": "0.36.0"}, {"version": "0.37.0"}]`,
+	}
+
+	var primaryFetches int
+
+	primary := ProviderFunc(func() ([]*Release, error) {
+		primaryFetches++
+		return []*Release{}, nil
+	})
+
+	spec := Spec{VersionsFrom: VersionsFrom{
+		Fallbacks: []VersionsFrom{
+			{HTTPJSONPath: HTTPJSONPath{URL: "https://releases.example.com/variant.json", Versions: "$[*].version"}},
+		},
+	}}
+
+	tracker, err := New(spec, Provider(primary), HttpGetter(vhttpget.NewTester(gets)), ProviderMiddlewares(WithCache(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "0.37.0" {
+			t.Errorf("fetch %d: unexpected version: expected=0.37.0, got=%s", i, latest.Version)
+		}
+	}
+
+	// The empty result of the primary is never cached, whereas the releases of the fallback are
+	if primaryFetches != 2 {
+		t.Errorf("unexpected number of fetches from the primary: expected=2, got=%d", primaryFetches)
+	}
+}
+
+func TestTracker_LatestFastPath(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases/latest"}: `{"tag_name": "v0.37.0", "body": "notes", "published_at": "2020-03-01T00:00:00Z"}`,
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}:        `[{"tag_name": "v0.37.0"}, {"tag_name": "v0.36.2"}]`,
+	}
+
+	var urls []string
+
+	tracker, err := New(
+		Spec{LatestFastPath: true, VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}},
+		HttpGetter(vhttpget.NewTester(gets)),
+		URLRewriter(func(u string) string {
+			urls = append(urls, u)
+			return u
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.37.0" || latest.Description != "notes" {
+		t.Errorf("unexpected latest: %+v", latest)
+	}
+
+	if d := cmp.Diff([]string{"https://api.github.com/repos/mumoshu/variant/releases/latest"}, urls); d != "" {
+		t.Errorf("unexpected requests: %s", d)
+	}
+
+	urls = nil
+
+	latest, err = tracker.Latest("< 0.37.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.36.2" {
+		t.Errorf("unexpected latest: expected=0.36.2, got=%s", latest.Version)
+	}
+
+	if d := cmp.Diff([]string{"https://api.github.com/repos/mumoshu/variant/releases"}, urls); d != "" {
+		t.Errorf("unexpected requests: %s", d)
+	}
+}
+
+func TestTracker_LatestFastPath_SkippedForListOptions(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases/latest"}: `{"tag_name": "v0.36.2"}`,
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}:        `[{"tag_name": "v0.37.0+b"}, {"tag_name": "v0.37.0+a"}, {"tag_name": "v0.36.2"}]`,
+	}
+
+	testcases := []struct {
+		name     string
+		spec     Spec
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "versionComparator",
+			opts:     []Option{VersionComparator(BuildMetadataLess)},
+			expected: "0.37.0+b",
+		},
+		{
+			name:     "minReleases",
+			spec:     Spec{MinReleases: 2},
+			expected: "0.37.0+b",
+		},
+		{
+			name:     "duplicatePolicy",
+			spec:     Spec{DuplicatePolicy: DuplicatePolicyHighestMetadata},
+			expected: "0.37.0+b",
+		},
+		{
+			name:     "excludePrereleases",
+			spec:     Spec{ExcludePrereleases: true},
+			expected: "0.37.0+b",
+		},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var urls []string
+
+			spec := tc.spec
+			spec.LatestFastPath = true
+			spec.VersionsFrom = VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}
+
+			opts := append([]Option{
+				HttpGetter(vhttpget.NewTester(gets)),
+				URLRewriter(func(u string) string {
+					urls = append(urls, u)
+					return u
+				}),
+			}, tc.opts...)
+
+			tracker, err := New(spec, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest.Version != tc.expected {
+				t.Errorf("unexpected latest: expected=%s, got=%s", tc.expected, latest.Version)
+			}
+
+			if d := cmp.Diff([]string{"https://api.github.com/repos/mumoshu/variant/releases"}, urls); d != "" {
+				t.Errorf("unexpected requests: %s", d)
+			}
+		})
+	}
+}
+
+func TestTracker_LastFetchReport(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    githubTags:
+      source: mumoshu/variant
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/tags"}: `[{"name": "v0.34.0"}, {"name": "v0.33.0"}]`,
+	}
+
+	failing, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(map[vhttpget.TestGetInput]string{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := failing.GetReleases(); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	report := failing.LastFetchReport()
+	if len(report.Attempts) != 1 {
+		t.Fatalf("unexpected number of attempts: %+v", report)
+	}
+
+	if a := report.Attempts[0]; a.Provider != "githubTags" || a.Err == nil || a.Releases != 0 {
+		t.Errorf("unexpected attempt: %+v", a)
+	}
+
+	succeeding, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := succeeding.GetReleases(); err != nil {
+		t.Fatal(err)
+	}
+
+	report = succeeding.LastFetchReport()
+	if len(report.Attempts) != 1 {
+		t.Fatalf("unexpected number of attempts: %+v", report)
+	}
+
+	if a := report.Attempts[0]; a.Provider != "githubTags" || a.Err != nil || a.Releases != 2 {
+		t.Errorf("unexpected attempt: %+v", a)
+	}
+}
+
+func TestTracker_MinReleases(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{MinReleases: 3}, "1.0.0\nnot-a-version\n1.1.0\n")
+
+	_, err := tracker.GetReleases()
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := "exec returned 2 valid releases, but at least 3 are required: check the versions source for misconfiguration"
+	if err.Error() != expected {
+		t.Errorf("unexpected error: expected=%q, got=%q", expected, err.Error())
+	}
+
+	tracker = newFakeExecTracker(t, Spec{MinReleases: 2}, "1.0.0\nnot-a-version\n1.1.0\n")
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rs) != 2 {
+		t.Errorf("unexpected number of releases: expected=2, got=%d", len(rs))
+	}
+
+	tracker = newFakeExecTracker(t, Spec{MinReleases: 2}, "1.0.0\nv1.0.0\n")
+
+	_, err = tracker.GetReleases()
+	if err == nil {
+		t.Fatal("expected error for duplicates, got none")
+	}
+
+	expected = "exec returned 1 valid releases, but at least 2 are required: check the versions source for misconfiguration"
+	if err.Error() != expected {
+		t.Errorf("unexpected error: expected=%q, got=%q", expected, err.Error())
+	}
+
+	tracker = newFakeExecTracker(t, Spec{MinReleases: 2, BuildMetadata: "prod"}, "1.0.0+prod\n1.1.0+dev\n")
+
+	if _, err := tracker.GetReleases(); err == nil {
+		t.Fatal("expected error for releases filtered by build metadata, got none")
+	}
+}
+
+func TestProvider_JSONPath_MultiDocumentYAML(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/releases.yaml": `name: myapp
+release:
+  version: 1.0.0
+---
+name: myapp
+release:
+  version: 1.2.0
+---
+name: myapp
+release:
+  version: 1.1.0
+`,
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	input := `releaseChannel:
+  versionsFrom:
+    jsonPath:
+      source: /work/releases.yaml
+      versions: "$.release.version"
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	stable, err := New(conf.ReleaseChannel, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := stable.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0", "1.2.0"}, vs); d != "" {
+		t.Errorf("unexpected versions: %s", d)
+	}
+}
+
+func TestTracker_LatestSource(t *testing.T) {
+	conf := Spec{SourceTemplate: "git::https://github.com/mumoshu/variant.git@examples?ref=v{{.Version}}"}
+
+	tracker := newFakeExecTracker(t, conf, "v0.30.0\nv0.31.1\nv0.32.0-rc.1\n")
+
+	src, err := tracker.LatestSource("< 0.32.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "git::https://github.com/mumoshu/variant.git@examples?ref=v0.31.1"
+	if src != expected {
+		t.Errorf("unexpected source: expected=%v, got=%v", expected, src)
+	}
+
+	tracker = newFakeExecTracker(t, Spec{}, "v0.30.0\n")
+
+	if _, err := tracker.LatestSource(""); err == nil {
+		t.Error("expected error for missing sourceTemplate, got none")
+	}
+}
+
+func TestTracker_DuplicatePolicy(t *testing.T) {
+	versions := "1.1.0\n1.2.0+9\n1.2.0+10\n1.2.0+3\n"
+
+	testcases := []struct {
+		policy   string
+		expected []string
+		err      string
+	}{
+		{policy: "", expected: []string{"1.1.0", "1.2.0+9", "1.2.0+10", "1.2.0+3"}},
+		{policy: "keepAll", expected: []string{"1.1.0", "1.2.0+9", "1.2.0+10", "1.2.0+3"}},
+		{policy: "first", expected: []string{"1.1.0", "1.2.0+9"}},
+		{policy: "last", expected: []string{"1.1.0", "1.2.0+3"}},
+		{policy: "highestMetadata", expected: []string{"1.1.0", "1.2.0+10"}},
+		{policy: "error", err: "duplicate releases found for 1.2.0: [1.2.0+9 1.2.0+10 1.2.0+3]"},
+		{policy: "random", err: `unsupported duplicatePolicy "random": it must be one of "first", "last", "highestMetadata", "error", or "keepAll"`},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.policy, func(t *testing.T) {
+			tracker := newFakeExecTracker(t, Spec{DuplicatePolicy: tc.policy}, versions)
+
+			rs, err := tracker.GetReleases()
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: expected=%q, got=%v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var vs []string
+			for _, r := range rs {
+				vs = append(vs, r.Version)
+			}
+
+			if d := cmp.Diff(tc.expected, vs); d != "" {
+				t.Errorf("unexpected releases: %s", d)
+			}
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest.Version != tc.expected[1] {
+				t.Errorf("unexpected latest: expected=%v, got=%v", tc.expected[1], latest.Version)
+			}
+		})
+	}
+}
+
+func TestTracker_DuplicatePolicy_NonAdjacent(t *testing.T) {
+	versions := "1.13.1\n1.13.0\n1.14.0\nv1.13.1\n1.12.9\n"
+
+	testcases := []struct {
+		policy   string
+		expected []string
+	}{
+		{policy: "", expected: []string{"1.13.1", "1.13.0", "1.14.0", "1.12.9"}},
+		{policy: "first", expected: []string{"1.13.1", "1.13.0", "1.14.0", "1.12.9"}},
+		{policy: "error"},
+	}
+
+	for _, tc := range testcases {
+		tracker := newFakeExecTracker(t, Spec{DuplicatePolicy: tc.policy, PreserveOrder: true}, versions)
+
+		rs, err := tracker.GetReleases()
+		if tc.expected == nil {
+			if err == nil || !strings.Contains(err.Error(), "duplicate releases found for 1.13.1") {
+				t.Errorf("policy=%q: unexpected error: %v", tc.policy, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("policy=%q: unexpected releases: %s", tc.policy, d)
+		}
+	}
+}
+
+func TestTracker_DuplicatePolicy_IdenticalVersions(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://releases.example.com/myapp.json"}: `[
+  {"version": "v1.2.0"},
+  {"version": "1.2.0", "notes": "the release notes"},
+  {"version": "1.1.0"},
+  {"version": "1.1.0"}
+]`,
+	}
+
+	testcases := []struct {
+		policy   string
+		expected []string
+	}{
+		{policy: "", expected: []string{"1.1.0", "1.2.0"}},
+		{policy: "keepAll", expected: []string{"1.1.0", "1.1.0", "1.2.0", "1.2.0"}},
+	}
+
+	for _, tc := range testcases {
+		spec := Spec{DuplicatePolicy: tc.policy, VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+			URL:     "https://releases.example.com/myapp.json",
+			Objects: ReleaseObjects{Path: "$[*]", Version: "$.version", Description: "$.notes"},
+		}}}
+
+		tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("policy=%q: unexpected releases: %s", tc.policy, d)
+		}
+
+		if tc.policy == "" && rs[1].Description != "the release notes" {
+			t.Errorf("expected the release with the description to be kept, got %+v", rs[1])
+		}
+	}
+}
+
+func TestTracker_Assets(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "1.0.0\n")
+
+	release := &Release{
+		Semver:  semver.MustParse("0.31.1"),
+		Version: "0.31.1",
+		Meta: map[string]interface{}{
+			"githubRelease": map[string]interface{}{
+				"tag_name": "v0.31.1",
+				"assets": []interface{}{
+					map[string]interface{}{
+						"name":                 "variant_0.31.1_linux_amd64.tar.gz",
+						"browser_download_url": "https://github.com/mumoshu/variant/releases/download/v0.31.1/variant_0.31.1_linux_amd64.tar.gz",
+					},
+					map[string]interface{}{
+						"name":                 "variant_0.31.1_checksums.txt",
+						"browser_download_url": "https://github.com/mumoshu/variant/releases/download/v0.31.1/variant_0.31.1_checksums.txt",
+					},
+				},
+			},
+		},
+	}
+
+	assets, err := tracker.Assets(release)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Asset{
+		{Name: "variant_0.31.1_linux_amd64.tar.gz", BrowserDownloadURL: "https://github.com/mumoshu/variant/releases/download/v0.31.1/variant_0.31.1_linux_amd64.tar.gz"},
+		{Name: "variant_0.31.1_checksums.txt", BrowserDownloadURL: "https://github.com/mumoshu/variant/releases/download/v0.31.1/variant_0.31.1_checksums.txt"},
+	}
+
+	if d := cmp.Diff(expected, assets); d != "" {
+		t.Errorf("unexpected assets: %s", d)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tracker.Assets(rs[0]); err != ErrAssetsNotSupported {
+		t.Errorf("unexpected error: expected=%v, got=%v", ErrAssetsNotSupported, err)
+	}
+}
+
+func TestTracker_PromotedStable(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0-rc.1\nv1.2.0\nv1.3.0-rc.1\n")
+
+	stable, promoted, err := tracker.PromotedStable("v1.2.0-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !promoted || stable.Version != "1.2.0" {
+		t.Errorf("unexpected result: promoted=%v, release=%v", promoted, stable)
+	}
+
+	stable, promoted, err = tracker.PromotedStable("1.3.0-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if promoted || stable != nil {
+		t.Errorf("unexpected result: promoted=%v, release=%v", promoted, stable)
+	}
+
+	if _, _, err := tracker.PromotedStable("1.2.0"); err == nil {
+		t.Error("expected error for a stable version, got none")
+	}
+}
+
+func TestTracker_LatestN(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0\nv1.0.0\nv2.0.0\nv1.2.1\n")
+
+	testcases := []struct {
+		constraint string
+		n          int
+		expected   []string
+	}{
+		{constraint: "< 2.0.0", n: 3, expected: []string{"1.2.1", "1.2.0", "1.1.0"}},
+		{constraint: "", n: 2, expected: []string{"2.0.0", "1.2.1"}},
+		{constraint: "~1.2.0", n: 5, expected: []string{"1.2.1", "1.2.0"}},
+		{constraint: "> 3.0.0", n: 1, expected: nil},
+	}
+
+	for _, tc := range testcases {
+		rs, err := tracker.LatestN(tc.constraint, tc.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("constraint=%q, n=%d: %s", tc.constraint, tc.n, d)
+		}
+	}
+
+	if _, err := tracker.LatestN("", 0); err == nil {
+		t.Error("expected error for n=0, got none")
+	}
+}
+
+func TestTracker_Releases(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0\nv1.0.0\nv2.0.0-rc.1\n")
+
+	testcases := []struct {
+		opts     []ListOption
+		expected []string
+	}{
+		{opts: nil, expected: []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0-rc.1"}},
+		{opts: []ListOption{Descending()}, expected: []string{"2.0.0-rc.1", "1.2.0", "1.1.0", "1.0.0"}},
+		{opts: []ListOption{Constraint(">= 1.1.0"), Descending()}, expected: []string{"1.2.0", "1.1.0"}},
+	}
+
+	for i, tc := range testcases {
+		rs, err := tracker.Releases(tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("testcase %d: %s", i, d)
+		}
+	}
+}
+
+func TestTracker_ExcludePrereleases(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{ExcludePrereleases: true}, "v1.1.0\nv1.2.0-rc.1\nv1.1.1\n")
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.1.0", "1.1.1"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	testcases := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "", expected: "1.1.1"},
+		{constraint: ">= 1.0.0-0", expected: "1.2.0-rc.1"},
+		{constraint: ">= 1.2.0-rc.1", expected: "1.2.0-rc.1"},
+	}
+
+	for _, tc := range testcases {
+		latest, err := tracker.Latest(tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version: constraint=%q, expected=%v, got=%v", tc.constraint, tc.expected, latest.Version)
+		}
+	}
+}
+
+func TestTracker_IsLatest(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0\nv2.0.0-rc.1\n")
+
+	testcases := []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		{version: "v1.2.0", constraint: "< 2.0.0-0", expected: true},
+		{version: "1.2.0", constraint: "< 2.0.0-0", expected: true},
+		{version: "v1.1.0", constraint: "< 2.0.0-0", expected: false},
+		{version: "1.2.0", constraint: "", expected: false},
+	}
+
+	for _, tc := range testcases {
+		got, err := tracker.IsLatest(tc.version, tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("unexpected result: version=%s, constraint=%q, expected=%v, got=%v", tc.version, tc.constraint, tc.expected, got)
+		}
+	}
+
+	if _, err := tracker.IsLatest("not-a-version", ""); err == nil {
+		t.Error("expected error for an unparseable version, got none")
+	}
+}
+
+func TestTracker_Next(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.3.0\nv1.2.0\nv1.2.1\nv2.0.0\n")
+
+	testcases := []struct {
+		current    string
+		constraint string
+		expected   string
+	}{
+		{current: "v1.1.0", constraint: "", expected: "1.2.0"},
+		{current: "1.2.0", constraint: "", expected: "1.2.1"},
+		{current: "1.2.0", constraint: ">= 1.3.0", expected: "1.3.0"},
+		{current: "1.1.5", constraint: "", expected: "1.2.0"},
+	}
+
+	for _, tc := range testcases {
+		next, err := tracker.Next(tc.current, tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if next.Version != tc.expected {
+			t.Errorf("unexpected next: current=%s, constraint=%q, expected=%s, got=%s", tc.current, tc.constraint, tc.expected, next.Version)
+		}
+	}
+
+	if _, err := tracker.Next("1.3.0", "< 2.0.0"); !errors.Is(err, ErrAlreadyLatest) {
+		t.Errorf("expected ErrAlreadyLatest, got %v", err)
+	}
+}
+
+func TestTracker_PreserveOrder(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{PreserveOrder: true}, "1.2.0\n1.10.0\n1.3.0\n")
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.2.0", "1.10.0", "1.3.0"}, vs); d != "" {
+		t.Errorf("unexpected releases: %s", d)
+	}
+
+	testcases := []struct {
+		constraint string
+		expected   string
+	}{
+		{constraint: "", expected: "1.2.0"},
+		{constraint: ">= 1.3.0", expected: "1.10.0"},
+	}
+
+	for _, tc := range testcases {
+		latest, err := tracker.Latest(tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected latest: constraint=%q, expected=%s, got=%s", tc.constraint, tc.expected, latest.Version)
+		}
+	}
+
+	latestN, err := tracker.LatestN("", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(latestN) != 2 || latestN[0].Version != "1.2.0" || latestN[1].Version != "1.10.0" {
+		t.Errorf("unexpected latest n: %v", latestN)
+	}
+
+	next, err := tracker.Next("1.2.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next.Version != "1.3.0" {
+		t.Errorf("unexpected next: expected=1.3.0, got=%s", next.Version)
+	}
+}
+
+func TestTracker_Has(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{ExcludePrereleases: true}, "v1.1.0\nv1.2.0\nv2.0.0-rc.1\n")
+
+	testcases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "v1.2.0", expected: true},
+		{version: "1.2.0", expected: true},
+		{version: "1.2", expected: true},
+		{version: "2.0.0-rc.1", expected: true},
+		{version: "1.3.0", expected: false},
+	}
+
+	for _, tc := range testcases {
+		got, err := tracker.Has(tc.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("unexpected result: version=%s, expected=%v, got=%v", tc.version, tc.expected, got)
+		}
+	}
+
+	if _, err := tracker.Has("not-a-version"); err == nil {
+		t.Error("expected error for an unparseable version, got none")
+	}
+}
+
+func TestTracker_BumpType(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0-rc.1\nv1.2.0-rc.2\nv1.2.0\nv1.2.1\nv2.0.0\n")
+
+	testcases := []struct {
+		baseline   string
+		constraint string
+		expected   string
+		latest     string
+	}{
+		{baseline: "1.2.1", constraint: "", expected: "major", latest: "2.0.0"},
+		{baseline: "v1.1.0", constraint: "< 1.2.1", expected: "minor", latest: "1.2.0"},
+		{baseline: "1.2.0", constraint: "< 2.0.0", expected: "patch", latest: "1.2.1"},
+		{baseline: "1.2.0-rc.1", constraint: "< 1.2.0-rc.3", expected: "prerelease", latest: "1.2.0-rc.2"},
+		{baseline: "1.2.0-rc.2", constraint: "<= 1.2.0", expected: "prerelease", latest: "1.2.0"},
+		{baseline: "2.0.0", constraint: "", expected: "none", latest: "2.0.0"},
+		{baseline: "3.0.0", constraint: "", expected: "none", latest: "2.0.0"},
+	}
+
+	for _, tc := range testcases {
+		bump, latest, err := tracker.BumpType(tc.baseline, tc.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bump != tc.expected || latest.Version != tc.latest {
+			t.Errorf("unexpected result: baseline=%s, constraint=%q, expected=%s %s, got=%s %s", tc.baseline, tc.constraint, tc.expected, tc.latest, bump, latest.Version)
+		}
+	}
+
+	if _, _, err := tracker.BumpType("not-a-version", ""); err == nil {
+		t.Error("expected error for an unparseable baseline, got none")
+	}
+}
+
+func TestTracker_ByChannel(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.2.0-rc.2\nv1.1.0\nv1.2.0-beta.1\nv1.2.0-alpha\nv1.2.0-rc1\nv1.2.0\nv1.2.0-beta.2\n")
+
+	channels, err := tracker.ByChannel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string][]string{}
+	for ch, rs := range channels {
+		for _, r := range rs {
+			got[ch] = append(got[ch], r.Version)
+		}
+	}
+
+	expected := map[string][]string{
+		"stable": {"1.1.0", "1.2.0"},
+		"rc":     {"1.2.0-rc.2", "1.2.0-rc1"},
+		"beta":   {"1.2.0-beta.1", "1.2.0-beta.2"},
+		"alpha":  {"1.2.0-alpha"},
+	}
+
+	if d := cmp.Diff(expected, got); d != "" {
+		t.Errorf("%s", d)
+	}
+}
+
+func TestTracker_IgnoreFloatingTags(t *testing.T) {
+	versions := "latest\n1.0.0\nedge\n1.1.0\nMain\nnightly\ncanary\n"
+
+	testcases := []struct {
+		name     string
+		spec     string
+		floating []string
+	}{
+		{
+			name:     "default",
+			floating: []string{"latest", "edge", "Main", "nightly"},
+		},
+		{
+			name:     "custom",
+			spec:     `floatingTags: ["canary"]`,
+			floating: []string{"canary"},
+		},
+		{
+			name: "disabled",
+			spec: `ignoreFloatingTags: false`,
+		},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			var conf Spec
+			if err := yaml.Unmarshal([]byte(tc.spec), &conf); err != nil {
+				t.Fatal(err)
+			}
+
+			tracker := newFakeExecTracker(t, conf, versions)
+
+			var floating []string
+			for _, s := range strings.Split(versions, "\n") {
+				if tracker.isFloatingTag(s) {
+					floating = append(floating, s)
+				}
+			}
+
+			if d := cmp.Diff(tc.floating, floating); d != "" {
+				t.Errorf("unexpected floating tags: %s", d)
+			}
+
+			rs, err := tracker.GetReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var vs []string
+			for _, r := range rs {
+				vs = append(vs, r.Version)
+			}
+
+			if d := cmp.Diff([]string{"1.0.0", "1.1.0"}, vs); d != "" {
+				t.Errorf("unexpected releases: %s", d)
+			}
+		})
+	}
+}
+
+func TestProvider_JSONPath_FilterExpression(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/releases.json": `[
+  {"version": "1.1.0", "stable": true, "channel": "lts"},
+  {"version": "1.2.0", "stable": true, "channel": "current"},
+  {"version": "1.3.0", "stable": false, "channel": "current"}
+]`,
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	testcases := []struct {
+		versions string
+		expected []string
+	}{
+		{versions: "$[?(@.stable==true)].version", expected: []string{"1.1.0", "1.2.0"}},
+		{versions: `$[?(@.stable && @.channel != "lts")].version`, expected: []string{"1.2.0"}},
+		{versions: "$[?(!@.stable)].version", expected: []string{"1.3.0"}},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(tc.versions, func(t *testing.T) {
+			conf := Spec{VersionsFrom: VersionsFrom{JSONPath: GetterJSONPath{Source: "/work/releases.json", Versions: tc.versions}}}
+
+			stable, err := New(conf, FS(fs), WD("/work"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rs, err := stable.GetReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var vs []string
+			for _, r := range rs {
+				vs = append(vs, r.Version)
+			}
+
+			if d := cmp.Diff(tc.expected, vs); d != "" {
+				t.Errorf("unexpected versions: %s", d)
+			}
+		})
+	}
+}
+
+func TestProvider_HTTPJSONPath_FilterExpression(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://releases.example.com/myapp.json"}: `[{"version":"1.2.0","stable":true},{"version":"1.3.0-rc.1","stable":false}]`,
+	}
+
+	tracker := newFakeExecTracker(t, Spec{}, "", HttpGetter(vhttpget.NewTester(gets)))
+
+	pp := &httpJsonPathProvider{
+		url:      "https://releases.example.com/myapp.json",
+		jsonpath: "$[?(@.stable==true)].version",
+		runtime:  tracker,
+	}
+
+	rs, err := pp.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rs) != 1 || rs[0].Version != "1.2.0" {
+		t.Errorf("unexpected releases: %v", rs)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTracker_HTTPClient(t *testing.T) {
+	var urls []string
+
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.URL.String())
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"items": [{"version": "1.0.0"}, {"version": "1.1.0"}]}`)),
+		}, nil
+	})}
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      "https://releases.example.com/myapp.json",
+		Versions: "$.items[*].version",
+	}}}
+
+	tracker, err := New(spec, HTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.1.0" {
+		t.Errorf("unexpected version: expected=1.1.0, got=%s", latest.Version)
+	}
+
+	if d := cmp.Diff([]string{"https://releases.example.com/myapp.json"}, urls); d != "" {
+		t.Errorf("unexpected requests: %s", d)
+	}
+}
+
+func TestTracker_Proxy(t *testing.T) {
+	var hosts []string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A proxy receives the absolute URL of the request
+		hosts = append(hosts, r.URL.Host)
+
+		fmt.Fprint(w, `{"items": [{"version": "1.0.0"}, {"version": "1.1.0"}]}`)
+	}))
+	defer proxy.Close()
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:      "http://releases.example.com/myapp.json",
+		Versions: "$.items[*].version",
+	}}}
+
+	tracker, err := New(spec, Proxy(proxy.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.1.0" {
+		t.Errorf("unexpected version: expected=1.1.0, got=%s", latest.Version)
+	}
+
+	if d := cmp.Diff([]string{"releases.example.com"}, hosts); d != "" {
+		t.Errorf("unexpected requests: %s", d)
+	}
+
+	if _, err := New(spec, Proxy(proxy.URL), HTTPClient(&http.Client{})); err == nil {
+		t.Error("expected an error for the proxy along with the http client")
+	}
+
+	if _, err := New(spec, Proxy("proxy.example.com")); err == nil {
+		t.Error("expected an error for the proxy url lacking the scheme")
+	}
+}
+
+func TestTracker_UserAgent(t *testing.T) {
+	var agents []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+
+		fmt.Fprint(w, `{"items": [{"version": "1.0.0"}]}`)
+	}))
+	defer srv.Close()
+
+	testcases := []struct {
+		opts     []Option
+		headers  map[string]string
+		expected string
+	}{
+		{expected: vhttpget.DefaultUserAgent},
+		{opts: []Option{UserAgent("myapp/1.0")}, expected: "myapp/1.0"},
+		{opts: []Option{UserAgent("myapp/1.0")}, headers: map[string]string{"User-Agent": "custom"}, expected: "custom"},
+	}
+
+	for i, tc := range testcases {
+		agents = nil
+
+		spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+			URL:      srv.URL + "/myapp.json",
+			Versions: "$.items[*].version",
+			Headers:  tc.headers,
+		}}}
+
+		tracker, err := New(spec, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tracker.GetReleases(); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+
+		if d := cmp.Diff([]string{tc.expected}, agents); d != "" {
+			t.Errorf("case %d: unexpected user agents: %s", i, d)
+		}
+	}
+
+	if !strings.HasPrefix(vhttpget.DefaultUserAgent, "variantdev-mod") {
+		t.Errorf("unexpected default user agent: %s", vhttpget.DefaultUserAgent)
+	}
+}
+
+func TestValidateConstraint(t *testing.T) {
+	for _, c := range []string{"", ">= 1.2, < 2", "~1.2.0", "> 1.0.0-0"} {
+		if err := ValidateConstraint(c); err != nil {
+			t.Errorf("%q: unexpected error: %v", c, err)
+		}
+	}
+
+	for _, c := range []string{">= foo", "1.2.0 ||| 2"} {
+		if err := ValidateConstraint(c); err == nil {
+			t.Errorf("%q: expected an error", c)
+		}
+	}
+
+	var fetches int
+
+	tracker, err := New(Spec{}, Provider(ProviderFunc(func() ([]*Release, error) {
+		fetches++
+		return nil, nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tracker.Latest(">= foo")
+	if err == nil || !strings.Contains(err.Error(), `invalid constraint ">= foo"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if fetches != 0 {
+		t.Errorf("unexpected fetches for the invalid constraint: %d", fetches)
+	}
+}
+
+func TestTracker_IncludeExcludeVersions(t *testing.T) {
+	versions := []string{"v1.4.0", "v1.4.1", "v1.4.2", "v1.5.0"}
+
+	testcases := []struct {
+		spec     Spec
+		expected []string
+	}{
+		{spec: Spec{ExcludeVersions: []string{"1.4.2"}}, expected: []string{"1.4.0", "1.4.1", "1.5.0"}},
+		{spec: Spec{IncludeVersions: []string{"v1.4.1", "1.4.2"}}, expected: []string{"1.4.1", "1.4.2"}},
+		{spec: Spec{IncludeVersions: []string{"1.4.1", "1.4.2"}, ExcludeVersions: []string{"v1.4.2"}}, expected: []string{"1.4.1"}},
+	}
+
+	for i, tc := range testcases {
+		tracker, err := New(tc.spec, Provider(NewStaticProvider(versions...)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("case %d: unexpected versions: %s", i, d)
+		}
+	}
+
+	_, err := New(Spec{ExcludeVersions: []string{"foo"}}, Provider(NewStaticProvider(versions...)))
+	if err == nil || !strings.Contains(err.Error(), `excludeVersions[0]: parsing version "foo"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestProvider_JQ(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://releases.example.com/myapp.json"}: `{"items": [{"version": "1.1.0", "stable": true}, {"version": "1.2.0", "stable": true}, {"version": "1.3.0", "stable": false}]}`,
+	}
+
+	testcases := []struct {
+		jq       string
+		expected []string
+	}{
+		{jq: `[.items[] | select(.stable) | .version]`, expected: []string{"1.1.0", "1.2.0"}},
+		{jq: `.items[] | "v" + .version`, expected: []string{"1.1.0", "1.2.0", "1.3.0"}},
+		{jq: `.items | map({(.version): .stable}) | add`, expected: []string{"1.1.0", "1.2.0", "1.3.0"}},
+	}
+
+	for _, tc := range testcases {
+		spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+			URL:      "https://releases.example.com/myapp.json",
+			Versions: "$.ignored",
+			JQ:       tc.jq,
+		}}}
+
+		tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatalf("jq=%s: %v", tc.jq, err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("jq=%s: unexpected versions: %s", tc.jq, d)
+		}
+	}
+}
+
+func TestProvider_HTTPJSONPath_Objects(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://releases.example.com/myapp.json"}: `[
+  {"version": "1.1.0", "date": "2020-01-01T00:00:00Z", "notes": "first"},
+  {"version": "1.2.0", "date": "2020-02-01T00:00:00Z", "notes": "second"}
+]`,
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{HTTPJSONPath: HTTPJSONPath{
+		URL:     "https://releases.example.com/myapp.json",
+		Objects: ReleaseObjects{Path: "$[*]", Version: "$.version", PublishedAt: "$.date", Description: "$.notes"},
+	}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.2.0" || latest.Description != "second" {
+		t.Errorf("unexpected release: %+v", latest)
+	}
+
+	if want := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC); !latest.PublishedAt.Equal(want) {
+		t.Errorf("unexpected publish date: want %v, got %v", want, latest.PublishedAt)
+	}
+
+	if _, ok := latest.Meta["httpJSONPath"]; !ok {
+		t.Errorf("missing the object in meta: %v", latest.Meta)
+	}
+}
+
+func TestProvider_JSONPath_JQ(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/releases.yaml": `releases:
+- tag: r1.1.0
+- tag: r1.2.0
+`,
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	conf := Spec{VersionsFrom: VersionsFrom{JSONPath: GetterJSONPath{
+		Source: "/work/releases.yaml",
+		JQ:     `[.releases[].tag | ltrimstr("r")]`,
+	}}}
+
+	tracker, err := New(conf, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.2.0" {
+		t.Errorf("unexpected version: expected=1.2.0, got=%s", latest.Version)
+	}
+}
+
+func TestProvider_JSONPath_Objects(t *testing.T) {
+	files := map[string]interface{}{
+		"/work/releases.yaml": `- version: 1.1.0
+  date: "2020-01-01T00:00:00Z"
+---
+- version: 1.2.0
+  date: "2020-02-01T00:00:00Z"
+`,
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+
+	conf := Spec{VersionsFrom: VersionsFrom{JSONPath: GetterJSONPath{
+		Source:  "/work/releases.yaml",
+		Objects: ReleaseObjects{Path: "$[*]", Version: "$.version", PublishedAt: "$.date"},
+	}}}
+
+	tracker, err := New(conf, FS(fs), WD("/work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rs) != 2 || rs[0].Version != "1.1.0" || rs[1].Version != "1.2.0" {
+		t.Fatalf("unexpected releases: %v", rs)
+	}
+
+	if rs[0].PublishedAt.IsZero() || rs[1].PublishedAt.IsZero() {
+		t.Errorf("missing publish dates: %v", rs)
+	}
+}
+
+func TestProvider_GitHubTags_FullURLSource(t *testing.T) {
+	for _, source := range []string{"mumoshu/variant", "https://github.com/mumoshu/variant", "https://github.com/mumoshu/variant.git"} {
+		t.Run(source, func(t *testing.T) {
+			gets := map[vhttpget.TestGetInput]string{
+				vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/tags"}: `[{"name": "v0.34.0"}]`,
+			}
+
+			stable, err := New(Spec{VersionsFrom: VersionsFrom{GitHubTags: GitHubTags{Source: source}}}, HttpGetter(vhttpget.NewTester(gets)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			latest, err := stable.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := "0.34.0"
+			if latest.Version != expected {
+				t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+			}
+		})
+	}
+}
+
+func TestTracker_ReleasedWithin(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: `[
+  {"tag_name": "v0.37.0", "published_at": "2020-03-01T00:00:00Z"},
+  {"tag_name": "v0.36.2", "published_at": "2020-02-10T00:00:00Z"},
+  {"tag_name": "v0.36.1"},
+  {"tag_name": "v0.36.0", "published_at": "2020-01-20T00:00:00Z"},
+  {"tag_name": "v0.35.0", "published_at": "2020-01-10T00:00:00Z"},
+  {"tag_name": "v0.34.0", "published_at": "2019-12-01T00:00:00Z"}
+]`,
+	}
+
+	tracker, err := New(Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}}, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.ReleasedWithin("v0.35.0", 14*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"0.36.0"}, got); d != "" {
+		t.Errorf("%s", d)
+	}
+
+	if _, err := tracker.ReleasedWithin("0.36.1", time.Hour); err == nil {
+		t.Errorf("expected error for a base release lacking the publish date")
+	}
+}
+
+func TestProvider_GitHubReleases_DraftsAndPrereleases(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: `[
+  {"tag_name": "v0.38.0", "draft": true, "prerelease": false},
+  {"tag_name": "v0.37.0", "draft": false, "prerelease": true},
+  {"tag_name": "v0.36.0", "draft": false, "prerelease": false}
+]`,
+	}
+
+	testcases := []struct {
+		spec     GitHubReleases
+		expected string
+	}{
+		{spec: GitHubReleases{}, expected: "0.36.0"},
+		{spec: GitHubReleases{IncludePrereleases: true}, expected: "0.37.0"},
+		{spec: GitHubReleases{IncludeDrafts: true}, expected: "0.38.0"},
+	}
+
+	for _, tc := range testcases {
+		tc.spec.Source = "mumoshu/variant"
+
+		tracker, err := New(Spec{VersionsFrom: VersionsFrom{GitHubReleases: tc.spec}}, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version: includeDrafts=%v, includePrereleases=%v, expected=%s, got=%s", tc.spec.IncludeDrafts, tc.spec.IncludePrereleases, tc.expected, latest.Version)
+		}
+	}
+}
+
+func TestProvider_GitHubReleases_PageOfPrereleases(t *testing.T) {
+	page2 := "https://api.github.com/repositories/64372901/releases?page=2"
+
+	responses := map[vhttpget.TestGetInput]vhttpget.Response{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: {
+			Header: http.Header{"Link": []string{`<` + page2 + `>; rel="next", <` + page2 + `>; rel="last"`}},
+			Body:   `[{"tag_name": "v0.38.0-rc.2", "prerelease": true}, {"tag_name": "v0.38.0", "draft": true}]`,
+		},
+		vhttpget.TestGetInput{URL: page2}: {
+			Body: `[{"tag_name": "v0.37.0"}, {"tag_name": "v0.36.0"}]`,
+		},
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewResponseTester(responses)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "0.37.0" {
+		t.Errorf("unexpected version: expected=0.37.0, got=%s", latest.Version)
+	}
+}
+
+func TestProvider_VersionsOverride(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     VersionsFrom
+		url      string
+		body     string
+		expected string
+	}{
+		{
+			name:     "githubReleases",
+			spec:     VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant", Versions: "$[?(@.prerelease == false)].tag_name"}},
+			url:      "https://api.github.com/repos/mumoshu/variant/releases",
+			body:     `[{"tag_name": "v0.37.0-rc.1", "prerelease": true}, {"tag_name": "v0.36.0", "prerelease": false}]`,
+			expected: "0.36.0",
+		},
+		{
+			name:     "dockerImageTags",
+			spec:     VersionsFrom{DockerImageTags: DockerImageTags{Source: "mumoshu/helmfile-chatops", Versions: `$.results[?(@.name != "0.3.0")].name`}},
+			url:      "https://registry.hub.docker.com/v2/repositories/mumoshu/helmfile-chatops/tags/?page_size=1000",
+			body:     `{"next": null, "results": [{"name": "0.3.0"}, {"name": "0.2.0"}]}`,
+			expected: "0.2.0",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gets := map[vhttpget.TestGetInput]string{
+				vhttpget.TestGetInput{URL: tc.url}: tc.body,
+			}
+
+			tracker, err := New(Spec{VersionsFrom: tc.spec}, HttpGetter(vhttpget.NewTester(gets)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if latest.Version != tc.expected {
+				t.Errorf("unexpected version: expected=%s, got=%s", tc.expected, latest.Version)
+			}
+		})
+	}
+}
+
+func TestTracker_ProviderKind(t *testing.T) {
+	testcases := []struct {
+		versionsFrom VersionsFrom
+		kind, url    string
+	}{
+		{
+			versionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}},
+			kind:         "githubReleases",
+			url:          "https://api.github.com/repos/mumoshu/variant/releases",
+		},
+		{
+			versionsFrom: VersionsFrom{DockerImageTags: DockerImageTags{Source: "mumoshu/helmfile-chatops"}},
+			kind:         "dockerImageTags",
+			url:          "https://registry.hub.docker.com/v2/repositories/mumoshu/helmfile-chatops/tags/",
+		},
+		{
+			versionsFrom: VersionsFrom{GitTags: GitTags{Source: "github.com/mumoshu/variant"}},
+			kind:         "gitTags",
+			url:          "https://github.com/mumoshu/variant.git",
+		},
+		{
+			versionsFrom: VersionsFrom{JSONPath: GetterJSONPath{Source: "https://example.com/releases.json", Versions: "$[*].version"}},
+			kind:         "jsonPath",
+			url:          "https://example.com/releases.json",
+		},
+		{
+			versionsFrom: VersionsFrom{Exec: Exec{Command: "list-versions"}},
+			kind:         "exec",
+		},
+	}
+
+	for _, tc := range testcases {
+		tracker, err := New(Spec{VersionsFrom: tc.versionsFrom})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if kind := tracker.ProviderKind(); kind != tc.kind {
+			t.Errorf("unexpected kind: expected=%s, got=%s", tc.kind, kind)
+		}
+
+		if url := tracker.SourceURL(); url != tc.url {
+			t.Errorf("%s: unexpected url: expected=%s, got=%s", tc.kind, tc.url, url)
+		}
+	}
+}
+
+func TestTracker_LatestStable(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.1.0\nv1.2.0+build.1\nv1.3.0-rc.1\n")
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "1.3.0-rc.1" {
+		t.Errorf("unexpected latest version: expected=1.3.0-rc.1, got=%s", latest.Version)
+	}
+
+	stable, err := tracker.LatestStable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stable.Version != "1.2.0+build.1" {
+		t.Errorf("unexpected latest stable version: expected=1.2.0+build.1, got=%s", stable.Version)
+	}
+
+	_, err = newFakeExecTracker(t, Spec{}, "v1.3.0-rc.1\n").LatestStable()
+	if !errors.Is(err, ErrNoMatchingRelease) {
+		t.Errorf("expected ErrNoMatchingRelease, got %v", err)
+	}
+}
+
+func TestTracker_LatestPatchAndMinor(t *testing.T) {
+	tracker := newFakeExecTracker(t, Spec{}, "v1.3.9\nv1.4.0\nv1.4.7\nv1.4.8-rc.1\nv1.9.2\nv2.0.0\nv2.1.0-rc.1\n")
+
+	patch, err := tracker.LatestPatch(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if patch.Version != "1.4.7" {
+		t.Errorf("unexpected latest patch: expected=1.4.7, got=%s", patch.Version)
+	}
+
+	minor, err := tracker.LatestMinor(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if minor.Version != "1.9.2" {
+		t.Errorf("unexpected latest minor: expected=1.9.2, got=%s", minor.Version)
+	}
+
+	minor, err = tracker.LatestMinor(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if minor.Version != "2.0.0" {
+		t.Errorf("unexpected latest minor: expected=2.0.0, got=%s", minor.Version)
+	}
+
+	if _, err := tracker.LatestPatch(1, 5); !errors.Is(err, ErrNoMatchingRelease) {
+		t.Errorf("expected ErrNoMatchingRelease, got %v", err)
+	}
+}
+
+func TestTracker_LatestBefore(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.hub.docker.com/v2/repositories/mumoshu/helmfile-chatops/tags/?page_size=1000"}: `{"next": null, "results": [
+  {"name": "0.3.0", "last_updated": "2019-09-01T00:00:00.123456Z"},
+  {"name": "0.2.1"},
+  {"name": "0.2.0", "last_updated": "2019-07-02T07:02:05.424914Z"},
+  {"name": "0.1.0", "last_updated": "2019-07-02T06:51:44.860914Z"}
+]}`,
+	}
+
+	tracker, err := New(Spec{VersionsFrom: VersionsFrom{DockerImageTags: DockerImageTags{Source: "mumoshu/helmfile-chatops"}}}, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		before   time.Time
+		expected string
+	}{
+		{before: time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC), expected: "0.2.0"},
+		{before: time.Date(2019, 7, 2, 7, 0, 0, 0, time.UTC), expected: "0.1.0"},
+		{before: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), expected: "0.3.0"},
+	}
+
+	for _, tc := range testcases {
+		latest, err := tracker.LatestBefore("", tc.before)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version before %v: expected=%s, got=%s", tc.before, tc.expected, latest.Version)
+		}
+	}
+
+	if _, err := tracker.LatestBefore("", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected error when no release was published before the time, got none")
+	}
+}
+
+func TestProvider_HTTPJSONPath_CursorPagination(t *testing.T) {
+	testcases := []struct {
+		cursor   string
+		nextPage string
+	}{
+		{
+			cursor:   `param: after`,
+			nextPage: "https://api.example.com/releases?after=abc%3D%3D&limit=2",
+		},
+		{
+			cursor:   `template: "{{.URL}}&page_token={{.Cursor}}"`,
+			nextPage: "https://api.example.com/releases?limit=2&page_token=abc%3D%3D",
+		},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			input := `releaseChannel:
+  versionsFrom:
+    httpJSONPath:
+      url: https://api.example.com/releases?limit=2
+      versions: $.items[*].version
+      cursor:
+        path: $.next_cursor
+        ` + tc.cursor + `
+`
+
+			conf := &Config{}
+			if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+				t.Fatal(err)
+			}
+
+			gets := map[vhttpget.TestGetInput]string{
+				vhttpget.TestGetInput{URL: "https://api.example.com/releases?limit=2"}: `{"items": [{"version": "1.0.0"}, {"version": "1.1.0"}], "next_cursor": "abc=="}`,
+				vhttpget.TestGetInput{URL: tc.nextPage}:                                `{"items": [{"version": "1.2.0"}], "next_cursor": ""}`,
+			}
+
+			tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rs, err := tracker.GetReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, r := range rs {
+				got = append(got, r.Version)
+			}
+
+			if d := cmp.Diff([]string{"1.0.0", "1.1.0", "1.2.0"}, got); d != "" {
+				t.Errorf("%s", d)
+			}
+		})
+	}
+}
+
+func TestTracker_PreferStableOnTie(t *testing.T) {
+	versions := "v1.2.0-rc.1+build.9\nv1.2.0+build.1\nv1.2.0-rc.2\nv1.1.0\n"
+
+	disabled := false
+
+	testcases := []struct {
+		prefer   *bool
+		expected string
+	}{
+		{prefer: nil, expected: "1.2.0+build.1"},
+		{prefer: &disabled, expected: "1.2.0-rc.2"},
+	}
+
+	for _, tc := range testcases {
+		tracker := newFakeExecTracker(t, Spec{PreferStableOnTie: tc.prefer}, versions)
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version: preferStableOnTie=%v, expected=%v, got=%v", tc.prefer, tc.expected, latest.Version)
+		}
+	}
+}
+
+func TestTracker_SkipInvalidVersions(t *testing.T) {
+	versions := "v1.1.0\nrelease-candidate\nv1.0.0\nv1.0.1\n"
+
+	disabled := false
+
+	tracker := newFakeExecTracker(t, Spec{}, versions)
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.0.1", "1.1.0"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	strict := newFakeExecTracker(t, Spec{SkipInvalidVersions: &disabled}, versions)
+
+	_, err = strict.GetReleases()
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), `"release-candidate"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTracker_Filter(t *testing.T) {
+	versions := "v1.1.0\nbuild-456\n2021-10-01\nv1.0.0\n"
+
+	disabled := false
+
+	tracker := newFakeExecTracker(t, Spec{Filter: `^v\d+\.\d+\.\d+$`, SkipInvalidVersions: &disabled}, versions)
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0"}, vs); d != "" {
+		t.Error(d)
+	}
+
+	_, err = New(Spec{Filter: `^v(`, VersionsFrom: VersionsFrom{Exec: Exec{Command: "list-versions"}}})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if !strings.Contains(err.Error(), "filter") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTracker_TrimVersionPrefixAndSuffix(t *testing.T) {
+	testcases := []struct {
+		spec     Spec
+		versions string
+		expected string
+		semver   string
+	}{
+		{spec: Spec{TrimVersionPrefix: "release-"}, versions: "release-1.2.3\nrelease-1.10.0\n", expected: "release-1.10.0", semver: "1.10.0"},
+		{spec: Spec{TrimVersionSuffix: "-ubuntu"}, versions: "1.2.3-ubuntu\n1.2.4-ubuntu\n", expected: "1.2.4-ubuntu", semver: "1.2.4"},
+		{spec: Spec{TrimVersionPrefix: "release-"}, versions: "v1.2.3\n", expected: "1.2.3", semver: "1.2.3"},
+	}
+
+	for _, tc := range testcases {
+		tracker := newFakeExecTracker(t, tc.spec, tc.versions)
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version: expected=%s, got=%s", tc.expected, latest.Version)
+		}
+
+		if latest.Semver.String() != tc.semver {
+			t.Errorf("unexpected semver: expected=%s, got=%s", tc.semver, latest.Semver.String())
+		}
+
+		if latest.Semver.Prerelease() != "" {
+			t.Errorf("unexpected prerelease: %s", latest.Semver.Prerelease())
+		}
+	}
+}
+
+func TestProvider_GoProxy_Latest(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    goProxy:
+      module: github.com/Azure/azure-sdk-for-go
+      latestOnly: true
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://proxy.golang.org/github.com/!azure/azure-sdk-for-go/@latest"}: `{"Version":"v68.0.0+incompatible","Time":"2022-12-20T01:06:41Z"}`,
+	}
+
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Version != "68.0.0+incompatible" {
+		t.Errorf("unexpected version: expected=68.0.0+incompatible, got=%v", latest.Version)
+	}
+
+	expected := time.Date(2022, 12, 20, 1, 6, 41, 0, time.UTC)
+	if !latest.PublishedAt.Equal(expected) {
+		t.Errorf("unexpected publish date: expected=%v, got=%v", expected, latest.PublishedAt)
+	}
+}
+
+func TestProvider_GoProxy(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://proxy.example.com/github.com/variantdev/mod/@v/list"}:  "v0.9.0\nv0.10.0\nv0.10.1-rc.1\n",
+		vhttpget.TestGetInput{URL: "https://proxy.example.com/github.com/variantdev/vals/@v/list"}: "",
+		vhttpget.TestGetInput{URL: "https://proxy.example.com/github.com/variantdev/vals/@latest"}: `{"Version":"v0.0.0-20230101000000-abcdef123456"}`,
+	}
+
+	testcases := []struct {
+		module   string
+		expected []string
+	}{
+		{module: "github.com/variantdev/mod", expected: []string{"0.9.0", "0.10.0", "0.10.1-rc.1"}},
+		{module: "github.com/variantdev/vals", expected: []string{"0.0.0-20230101000000-abcdef123456"}},
+	}
+
+	for _, tc := range testcases {
+		spec := Spec{VersionsFrom: VersionsFrom{GoProxy: GoProxy{Module: tc.module, Proxy: "https://proxy.example.com/"}}}
+
+		tracker, err := New(spec, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("%s: %s", tc.module, d)
+		}
+	}
+}
+
+func TestProvider_NpmPackage(t *testing.T) {
+	input := `releaseChannel:
+  versionsFrom:
+    npmPackage:
+      package: "@types/node"
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://registry.npmjs.org/@types%2fnode"}: `{
+  "name": "@types/node",
+  "dist-tags": {"latest": "20.1.0"},
+  "versions": {
+    "18.0.0": {"name": "@types/node", "version": "18.0.0"},
+    "20.1.0": {"name": "@types/node", "version": "20.1.0"},
+    "19.2.1": {"name": "@types/node", "version": "19.2.1"}
+  }
+}`,
+	}
+
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	if d := cmp.Diff([]string{"18.0.0", "19.2.1", "20.1.0"}, vs); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestProvider_GitLabReleases(t *testing.T) {
+	body := `[
+  {"tag_name": "v1.1.0", "released_at": "2020-01-10T00:00:00.000Z"},
+  {"tag_name": "v1.2.0", "released_at": "2020-02-10T00:00:00.000Z"}
+]`
+
+	testcases := []struct {
+		spec GitLabReleases
+		url  string
+	}{
+		{
+			spec: GitLabReleases{Source: "group/subgroup/project"},
+			url:  "https://gitlab.com/api/v4/projects/group%2Fsubgroup%2Fproject/releases",
+		},
+		{
+			spec: GitLabReleases{Host: "gitlab.example.com", Source: "https://gitlab.example.com/group/project.git"},
+			url:  "https://gitlab.example.com/api/v4/projects/group%2Fproject/releases",
+		},
+		{
+			spec: GitLabReleases{Host: "gitlab.example.com", ProjectID: "42"},
+			url:  "https://gitlab.example.com/api/v4/projects/42/releases",
+		},
+	}
+
+	for _, tc := range testcases {
+		gets := map[vhttpget.TestGetInput]string{
+			vhttpget.TestGetInput{URL: tc.url}: body,
+		}
+
+		tracker, err := New(Spec{VersionsFrom: VersionsFrom{GitLabReleases: tc.spec}}, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != "1.2.0" {
+			t.Errorf("unexpected version: expected=1.2.0, got=%v", latest.Version)
+		}
+
+		expected := time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC)
+		if !latest.PublishedAt.Equal(expected) {
+			t.Errorf("unexpected publish date: expected=%v, got=%v", expected, latest.PublishedAt)
+		}
+	}
+}
+
+func TestProvider_GitLabTags(t *testing.T) {
+	defer setenv(t, "GITLAB_TOKEN", "secret")()
+
+	base := "https://gitlab.example.com/api/v4/projects/group%2Fproject/repository/tags"
+	page2 := base + "?page=2&per_page=100"
+	page3 := base + "?page=3&per_page=100"
+
+	auth := "Authorization: Bearer secret"
+
+	responses := map[vhttpget.TestGetInput]vhttpget.Response{
+		vhttpget.TestGetInput{URL: base + "?per_page=100", Headers: auth}: {
+			Header: http.Header{"Link": []string{`<` + page2 + `>; rel="next"`}, "X-Next-Page": []string{"2"}},
+			Body:   `[{"name": "v1.2.0", "commit": {"created_at": "2020-02-10T00:00:00.000Z"}}]`,
+		},
+		// Some instances strip the Link header, leaving X-Next-Page only
+		vhttpget.TestGetInput{URL: page2, Headers: auth}: {
+			Header: http.Header{"X-Next-Page": []string{"3"}},
+			Body:   `[{"name": "v1.1.0", "commit": {"created_at": "2020-01-10T00:00:00.000Z"}}]`,
+		},
+		vhttpget.TestGetInput{URL: page3, Headers: auth}: {
+			Header: http.Header{"X-Next-Page": []string{""}},
+			Body:   `[{"name": "v1.0.0", "commit": {"created_at": "2019-12-10T00:00:00.000Z"}}]`,
+		},
+	}
+
+	spec := Spec{VersionsFrom: VersionsFrom{GitLabTags: GitLabTags{
+		Host:   "gitlab.example.com",
+		Source: "https://gitlab.example.com/group/project.git",
+		Token:  "env:GITLAB_TOKEN",
+	}}}
+
+	tracker, err := New(spec, HttpGetter(vhttpget.NewResponseTester(responses)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := tracker.GetReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vs []string
+	for _, r := range rs {
+		vs = append(vs, r.Version)
+	}
+
+	sort.Strings(vs)
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0", "1.2.0"}, vs); d != "" {
+		t.Errorf("unexpected versions: %s", d)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC)
+	if !latest.PublishedAt.Equal(expected) {
+		t.Errorf("unexpected publish date: expected=%v, got=%v", expected, latest.PublishedAt)
+	}
+}
+
+func TestProvider_GitHubReleases_LinkPagination(t *testing.T) {
+	page2 := "https://api.github.com/repositories/64372901/releases?page=2"
+	page3 := "https://api.github.com/repositories/64372901/releases?page=3"
+
+	responses := map[vhttpget.TestGetInput]vhttpget.Response{
+		vhttpget.TestGetInput{URL: "https://api.github.com/repos/mumoshu/variant/releases"}: {
+			Header: http.Header{"Link": []string{`<` + page2 + `>; rel="next", <` + page3 + `>; rel="last"`}},
+			Body:   `[{"tag_name": "v0.36.0"}, {"tag_name": "v0.35.0"}]`,
+		},
+		vhttpget.TestGetInput{URL: page2}: {
+			Header: http.Header{"Link": []string{`<` + page3 + `>; rel="next", <` + page3 + `>; rel="last"`}},
+			Body:   `[{"tag_name": "v0.34.0"}, {"tag_name": "v0.33.0"}]`,
+		},
+		vhttpget.TestGetInput{URL: page3}: {
+			Header: http.Header{"Link": []string{`<https://api.github.com/repositories/64372901/releases?page=2>; rel="prev"`}},
+			Body:   `[{"tag_name": "v0.32.0"}]`,
+		},
+	}
+
+	testcases := []struct {
+		maxPages int
+		expected []string
+	}{
+		{maxPages: 0, expected: []string{"0.32.0", "0.33.0", "0.34.0", "0.35.0", "0.36.0"}},
+		{maxPages: 2, expected: []string{"0.33.0", "0.34.0", "0.35.0", "0.36.0"}},
+	}
+
+	for _, tc := range testcases {
+		spec := Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant", MaxPages: tc.maxPages}}}
+
+		tracker, err := New(spec, HttpGetter(vhttpget.NewResponseTester(responses)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rs, err := tracker.GetReleases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vs []string
+		for _, r := range rs {
+			vs = append(vs, r.Version)
+		}
+
+		sort.Strings(vs)
+
+		if d := cmp.Diff(tc.expected, vs); d != "" {
+			t.Errorf("maxPages=%d: %s", tc.maxPages, d)
+		}
+	}
+}
+
+func TestProvider_GitHubReleases_Token(t *testing.T) {
+	defer setenv(t, "GHE_TOKEN", "secret")()
+
+	input := `releaseChannel:
+  versionsFrom:
+    githubReleases:
+      host: github.example.com/api/v3
+      source: myorg/myapp
+      token: env:GHE_TOKEN
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://github.example.com/api/v3/repos/myorg/myapp/releases", Headers: "Authorization: token secret"}: `[{"tag_name": "v1.1.0"}, {"tag_name": "v1.0.0"}]`,
+	}
+
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1.1.0"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+}
+
+func TestProvider_GitHub_APIPath(t *testing.T) {
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://github.example.com/api/v3/repos/myorg/myapp/releases"}: `[{"tag_name": "v1.1.0"}]`,
+		vhttpget.TestGetInput{URL: "https://github.example.com/api/v3/repos/myorg/myapp/tags"}:     `[{"name": "v1.2.0"}]`,
+	}
+
+	testcases := []struct {
+		from     VersionsFrom
+		expected string
+	}{
+		{from: VersionsFrom{GitHubReleases: GitHubReleases{Host: "github.example.com", APIPath: "/api/v3", Source: "myorg/myapp"}}, expected: "1.1.0"},
+		{from: VersionsFrom{GitHubTags: GitHubTags{Host: "github.example.com", APIPath: "api/v3/", Source: "myorg/myapp"}}, expected: "1.2.0"},
+	}
+
+	for _, tc := range testcases {
+		tracker, err := New(Spec{VersionsFrom: tc.from}, HttpGetter(vhttpget.NewTester(gets)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latest, err := tracker.Latest("")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if latest.Version != tc.expected {
+			t.Errorf("unexpected version: expected=%v, got=%v", tc.expected, latest.Version)
+		}
+	}
+}
+
+func TestProvider_HTTPJSONPath_BearerToken(t *testing.T) {
+	defer setenv(t, "ARTIFACTS_API_TOKEN", "secret")()
+
+	input := `releaseChannel:
+  versionsFrom:
+    httpJSONPath:
+      url: https://artifacts.example.com/api/myapp/versions
+      versions: $[*].version
+      bearerToken: env:ARTIFACTS_API_TOKEN
+`
+
+	conf := &Config{}
+	if err := yaml.Unmarshal([]byte(input), conf); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := map[vhttpget.TestGetInput]string{
+		vhttpget.TestGetInput{URL: "https://artifacts.example.com/api/myapp/versions", Headers: "Authorization: Bearer secret"}: `[{"version": "1.1.0"}, {"version": "1.0.0"}]`,
+	}
+
+	tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := tracker.Latest("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1.1.0"
+	if latest.Version != expected {
+		t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+	}
+}
+
+func TestProvider_Headers(t *testing.T) {
+	defer setenv(t, "ARTIFACTS_API_KEY", "secret")()
+
+	testcases := []struct {
+		name  string
+		input string
+		url   string
+	}{
+		{
+			name: "httpJSONPath",
+			input: `releaseChannel:
+  versionsFrom:
+    httpJSONPath:
+      url: https://artifacts.example.com/api/myapp/versions
+      versions: $[*].version
+      headers:
+        X-Api-Key: $ARTIFACTS_API_KEY
+        Accept: application/json
+`,
+			url: "https://artifacts.example.com/api/myapp/versions",
+		},
+		{
+			name: "jsonPath",
+			input: `releaseChannel:
+  versionsFrom:
+    jsonPath:
+      source: https://artifacts.example.com/api/myapp/versions.json
+      versions: $[*].version
+      headers:
+        X-Api-Key: $ARTIFACTS_API_KEY
+        Accept: application/json
+`,
+			url: "https://artifacts.example.com/api/myapp/versions.json",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := &Config{}
+			if err := yaml.Unmarshal([]byte(tc.input), conf); err != nil {
+				t.Fatal(err)
+			}
+
+			gets := map[vhttpget.TestGetInput]string{
+				vhttpget.TestGetInput{URL: tc.url, Headers: "Accept: application/json\nX-Api-Key: secret"}: `[{"version": "1.1.0"}, {"version": "1.0.0"}]`,
+			}
+
+			tracker, err := New(conf.ReleaseChannel, HttpGetter(vhttpget.NewTester(gets)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			latest, err := tracker.Latest("")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := "1.1.0"
+			if latest.Version != expected {
+				t.Errorf("unexpected version: expected=%v, got=%v", expected, latest.Version)
+			}
+		})
+	}
+}
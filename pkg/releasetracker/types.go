@@ -1,6 +1,9 @@
 package releasetracker
 
-import "regexp"
+import (
+	"regexp"
+	"time"
+)
 
 type Config struct {
 	ReleaseChannel Spec `yaml:"releaseChannel"`
@@ -8,44 +11,507 @@ type Config struct {
 
 type Spec struct {
 	VersionsFrom VersionsFrom `yaml:"versionsFrom"`
+
+	// BuildMetadata keeps only releases whose semver build metadata equals to this, like `prod` for `1.2.0+prod`
+	BuildMetadata string `yaml:"buildMetadata"`
+
+	// MinReleases makes fetching releases fail when the source returned fewer valid releases than this,
+	// counted after filtering by BuildMetadata and collapsing duplicates.
+	// This catches a misconfigured source, like a jsonpath that happens to match only one string that parses as a version.
+	MinReleases int `yaml:"minReleases"`
+
+	// SourceTemplate is the go-getter URL to download the source of a release, like
+	// `git::https://github.com/org/repo.git?ref=v{{.Version}}`.
+	// `{{.Version}}` and `{{.Semver}}` are replaced with the version of the release.
+	SourceTemplate string `yaml:"sourceTemplate"`
+
+	// PreserveOrder keeps the releases in the order returned by the source, rather than sorting them by version,
+	// for sources like CalVer APIs returning the newest release first whose tags don't sort as semver.
+	// Latest then returns the first release in the source order that matches the constraint, and LatestN the first n.
+	// Constraints are still checked against the parsed versions. GetReleases and Releases return the source order,
+	// and duplicates are collapsed only when adjacent in the source.
+	PreserveOrder bool `yaml:"preserveOrder"`
+
+	// LatestFastPath makes Latest("") ask the source for the latest release with a single request, rather than listing
+	// all the releases, for sources that support it, which are githubReleases and goProxy.
+	// Note that the source decides which is the latest, like GitHub returning the most recent stable release rather than
+	// the highest version. Releases are listed as usual when the constraint is given or the source doesn't support it,
+	// and when the VersionComparator option, MinReleases, DuplicatePolicy or ExcludePrereleases is configured.
+	LatestFastPath bool `yaml:"latestFastPath"`
+
+	// DuplicatePolicy determines which release to keep when the source returned two or more releases with
+	// the same semver that differ only in build metadata, like `1.2.0+a` and `1.2.0+b`.
+	//
+	// `first` keeps the one that appeared first in the source, `last` keeps the last one,
+	// `highestMetadata` keeps the one with the highest build metadata, `error` fails fetching releases,
+	// and `keepAll` keeps all the releases as returned by the source.
+	//
+	// When empty, only the releases of the identical version, like `v1.2.0` and `1.2.0`, are collapsed into the first one,
+	// preferring the one with the description. The ones differing in build metadata are kept, and Latest picks the first one.
+	DuplicatePolicy string `yaml:"duplicatePolicy"`
+
+	// DuplicateKey determines which releases are duplicates of each other.
+	// `semver`, the default, ignores the "v" prefix and build metadata, so that `v1.2.0`, `1.2.0`, and `1.2.0+build` are
+	// collapsed into one. `semverWithMetadata` ignores only the prefix, keeping `1.2.0+a` and `1.2.0+b` as distinct releases.
+	DuplicateKey string `yaml:"duplicateKey"`
+
+	// IgnoreFloatingTags drops floating tags like `latest` that are moved across releases rather than naming a version,
+	// before parsing versions. Enabled by default.
+	IgnoreFloatingTags *bool `yaml:"ignoreFloatingTags"`
+
+	// FloatingTags overrides the tags to be ignored. Defaults to DefaultFloatingTags.
+	FloatingTags []string `yaml:"floatingTags"`
+
+	// VersionCapture is the regular expression to extract the version out of each string obtained from the source,
+	// like `(\d+\.\d+\.\d+)` for release titles like `Release 1.2.0 (stable)`.
+	// The first capture group is used as the version when there is one, otherwise the whole match.
+	// Strings that don't match are skipped.
+	VersionCapture string `yaml:"versionCapture"`
+
+	// Filter is the regular expression to select the strings obtained from the source before parsing versions,
+	// like `^v\d+\.\d+\.\d+$` to only track semver-looking tags in a repo with `build-456` and `2021-10-01` tags, too.
+	// Strings that don't match are skipped. It is applied before VersionCapture.
+	Filter string `yaml:"filter"`
+
+	// TrimVersionPrefix and TrimVersionSuffix are removed from each version string before parsing it as semver,
+	// like `release-` for `release-1.2.3` and `-ubuntu` for `1.2.3-ubuntu`.
+	// Release.Version keeps the original tag when either is removed, while Release.Semver is parsed from the rest.
+	TrimVersionPrefix string `yaml:"trimVersionPrefix"`
+	TrimVersionSuffix string `yaml:"trimVersionSuffix"`
+
+	// ExcludeVersions drops the releases of the versions, like a yanked `1.4.2` still listed by the source.
+	// Versions are compared as semver, so that `1.4.2` matches `v1.4.2`.
+	ExcludeVersions []string `yaml:"excludeVersions"`
+
+	// IncludeVersions keeps the releases of the versions only, when not empty. Versions are compared as semver.
+	// ExcludeVersions takes precedence.
+	IncludeVersions []string `yaml:"includeVersions"`
+
+	// MaxConcurrency is the maximum number of files read at once, for sources made of multiple files like
+	// jsonPath with `files`. Defaults to DefaultMaxConcurrency.
+	MaxConcurrency int `yaml:"maxConcurrency"`
+
+	// PartialResultsOnDeadline makes GetReleasesContext and LatestContext return the releases fetched so far,
+	// like the pages fetched before the deadline, instead of the deadline error.
+	// The error is still returned when nothing was fetched.
+	PartialResultsOnDeadline bool `yaml:"partialResultsOnDeadline"`
+
+	// RetryOnEmpty re-fetches releases when the source returned none, like when a tag was just published and
+	// the source is eventually consistent. Unlike WithRetry, this doesn't retry on errors.
+	RetryOnEmpty bool `yaml:"retryOnEmpty"`
+	// RetryOnEmptyAttempts is the number of fetches in total. Defaults to DefaultRetryOnEmptyAttempts
+	RetryOnEmptyAttempts int `yaml:"retryOnEmptyAttempts"`
+	// RetryOnEmptyBackoff is the wait before the first retry, which doubles on each retry. Defaults to DefaultRetryOnEmptyBackoff
+	RetryOnEmptyBackoff time.Duration `yaml:"retryOnEmptyBackoff"`
+
+	// PreferStableOnTie makes Latest choose the stable release over prereleases of the same core version,
+	// like `1.2.0` over `1.2.0-rc.1`, regardless of build metadata. Enabled by default, which agrees with the semver precedence.
+	//
+	// Set it to false to prefer the highest prerelease of the core version instead, e.g. for a channel that tracks
+	// release candidates even after they got promoted.
+	PreferStableOnTie *bool `yaml:"preferStableOnTie"`
+
+	// SkipInvalidVersions drops versions that don't parse as semver, like a stray `release-candidate` tag, logging them at V(1).
+	// Enabled by default, as the tracker has always ignored them.
+	//
+	// Set it to false to fail on the first invalid version instead, e.g. to catch a wrong jsonpath early.
+	SkipInvalidVersions *bool `yaml:"skipInvalidVersions"`
+
+	// ExcludePrereleases drops the releases with prerelease versions like `1.2.0-rc.1` from Latest and GetReleases.
+	// It is bypassed when the constraint passed to Latest names a prerelease, like `>= 1.2.0-rc.1`.
+	ExcludePrereleases bool `yaml:"excludePrereleases"`
 }
 
 type VersionsFrom struct {
-	Exec            Exec            `yaml:"exec"`
-	JSONPath        GetterJSONPath  `yaml:"jsonPath"`
-	GitTags         GitTags         `yaml:"gitTags"`
-	GitHubTags      GitHubTags      `yaml:"githubTags"`
-	GitHubReleases  GitHubReleases  `yaml:"githubReleases"`
-	DockerImageTags DockerImageTags `yaml:"dockerImageTags"`
+	Exec               Exec               `yaml:"exec"`
+	JSONPath           GetterJSONPath     `yaml:"jsonPath"`
+	GitTags            GitTags            `yaml:"gitTags"`
+	GitFile            GitFile            `yaml:"gitFile"`
+	HTTPJSONPath       HTTPJSONPath       `yaml:"httpJSONPath"`
+	GitHubTags         GitHubTags         `yaml:"githubTags"`
+	GitHubReleases     GitHubReleases     `yaml:"githubReleases"`
+	GitLabReleases     GitLabReleases     `yaml:"gitlabReleases"`
+	GitLabTags         GitLabTags         `yaml:"gitlabTags"`
+	BitbucketTags      BitbucketTags      `yaml:"bitbucketTags"`
+	DockerImageTags    DockerImageTags    `yaml:"dockerImageTags"`
+	ContainerImageTags ContainerImageTags `yaml:"containerImageTags"`
+	HelmChart          HelmChart          `yaml:"helmChart"`
+	HelmOCI            HelmOCI            `yaml:"helmOCI"`
+	Scoop              Scoop              `yaml:"scoop"`
+	GoProxy            GoProxy            `yaml:"goProxy"`
+	NpmPackage         NpmPackage         `yaml:"npmPackage"`
+	PyPIPackage        PyPIPackage        `yaml:"pypiPackage"`
+	MavenArtifact      MavenArtifact      `yaml:"mavenArtifact"`
+	ConsulKV           ConsulKV           `yaml:"consulKV"`
+	EtcdKV             EtcdKV             `yaml:"etcdKV"`
+	S3                 S3Bucket           `yaml:"s3"`
+	File               LocalFile          `yaml:"file"`
+
+	// Fallbacks are the versions sources tried in order when the source above fails or returns no releases,
+	// like gitTags for githubReleases hitting the API rate limit. Each of them must have exactly one source
+	Fallbacks []VersionsFrom `yaml:"fallbacks"`
 
 	ValidVersionPattern *regexp.Regexp
 }
 
+// Exec reads versions from the lines printed by the command, like `aws ecr describe-images ...`,
+// for tools that have no dedicated source. The stdout is split on newlines, and empty lines are dropped.
+// Windows line endings are accepted.
+//
+// The command is run directly with the args, without a shell, so it works on Windows as well.
+// Run the shell of your choice as the command for shell features, like `command: bash` with `args: ["-c", "..."]`,
+// or `command: cmd` with `args: ["/c", "..."]` on Windows.
 type Exec struct {
 	Command string   `yaml:"command"`
 	Args    []string `yaml:"args"`
+	// Env is added to the environment inherited from the process, like credentials for the command.
+	// Environment variables in the values are expanded, like `$API_TOKEN`, so that secrets aren't inlined
+	Env map[string]string `yaml:"env"`
+	// Dir is the working directory of the command, relative to the work dir of the tracker.
+	// Defaults to the current directory of the process. It requires the default command runner, not the one set via Commander
+	Dir string `yaml:"dir"`
 }
 
 type GetterJSONPath struct {
-	Source      string `yaml:"source"`
-	Versions    string `yaml:"versions"`
+	Source string `yaml:"source"`
+	// Versions is the JSONPath expression to extract versions, like `$[*].version`.
+	// Filter expressions are supported, like `$[?(@.stable == true)].version`.
+	Versions string `yaml:"versions"`
+	// JQ is the jq query to extract versions, like `[.[] | select(.stable) | .version]`, for transforms JSONPath
+	// can't express. It must emit a version, an array of versions, or a map whose keys are versions.
+	// Takes precedence over Versions
+	JQ          string `yaml:"jq"`
+	Description string `yaml:"description"`
+	// Files makes the source a directory, and versions are extracted from every file in it whose name matches
+	// this glob pattern, like `*.yaml`. Results from all the files are merged.
+	Files string `yaml:"files"`
+	// Headers are sent with the request when Source is an http or https URL, like `Authorization` or `X-Api-Key`.
+	// The source is then fetched directly rather than via go-getter. Environment variables in the values are expanded
+	Headers map[string]string `yaml:"headers"`
+	// Objects extracts releases from the objects in the document rather than version strings. Versions is ignored when set.
+	// It can't be used with Files
+	Objects ReleaseObjects `yaml:"objects"`
+}
+
+// HTTPJSONPath reads versions from a JSON or YAML document served by an HTTP API
+type HTTPJSONPath struct {
+	URL string `yaml:"url"`
+	// Versions is the JSONPath expression to extract versions from each page, like `$.items[*].version`
+	Versions string `yaml:"versions"`
+	// JQ is the jq query to extract versions from each page, like `[.items[] | "v" + .version]`, for transforms JSONPath
+	// can't express. It must emit a version, an array of versions, or a map whose keys are versions.
+	// Takes precedence over Versions
+	JQ string `yaml:"jq"`
+	// Cursor configures pagination for APIs that return an opaque cursor to the next page in the response body
+	Cursor CursorPagination `yaml:"cursor"`
+	// Headers are sent with the requests, like `Authorization` or `X-Api-Key`.
+	// Environment variables in the values are expanded, like `Bearer $API_TOKEN`
+	Headers map[string]string `yaml:"headers"`
+	// BearerToken is sent as `Authorization: Bearer <token>` header with the requests, for private APIs.
+	// It can be a secret reference like `env:API_TOKEN`
+	BearerToken string `yaml:"bearerToken"`
+	// Objects extracts releases from the objects in each page rather than version strings. Versions is ignored when set
+	Objects ReleaseObjects `yaml:"objects"`
+}
+
+// ReleaseObjects selects objects like `{"version":"1.2.3","date":"2020-01-02T03:04:05Z"}` by a JSONPath expression,
+// so that the publish date and the description of each release can be read from the fields next to the version.
+type ReleaseObjects struct {
+	// Path is the JSONPath expression selecting the objects, like `$[*]` or `$.items[*]`.
+	// Objects mode is disabled when empty.
+	Path string `yaml:"path"`
+	// Version is the JSONPath expression to the version in each object, like `$.version`
+	Version string `yaml:"version"`
+	// PublishedAt is the JSONPath expression to the RFC3339 publish date in each object, like `$.date`.
+	// Prefer the `$.` prefix, as a bare `date` is evaluated as the builtin function of the same name
+	PublishedAt string `yaml:"publishedAt"`
+	// Description is the JSONPath expression to the description in each object, like `$.notes`
 	Description string `yaml:"description"`
 }
 
+// CursorPagination describes how to request the next page from the cursor found in the current page.
+// Pages are requested until the cursor becomes empty.
+type CursorPagination struct {
+	// Path is the JSONPath expression to the cursor in the response body, like `$.next_cursor`.
+	// Pagination is disabled when empty.
+	Path string `yaml:"path"`
+	// Param is the query parameter set to the cursor in the URL of the next page, like `cursor`
+	Param string `yaml:"param"`
+	// Template is the go template to render the URL of the next page, like `{{.URL}}?after={{.Cursor}}`.
+	// `{{.URL}}` is the URL of the first page. Takes precedence over Param.
+	Template string `yaml:"template"`
+}
+
 type GitTags struct {
 	Source string `yaml:"source"`
+	// Protocol is the protocol used to list the tags of the repository, which is one of `https`, `ssh` and `git`.
+	// Defaults to `https`, as the unencrypted git protocol is blocked on many networks.
+	Protocol string `yaml:"protocol"`
+	// URL is the full URL of the repository passed to `git ls-remote` as-is, like `ssh://git@bitbucket.org/org/repo.git`
+	// or `git@gitlab.example.com:org/repo.git`. Source and Protocol are ignored when set.
+	// As git is run with the user's environment, private repositories work via the SSH agent or the git credential helpers.
+	URL string `yaml:"url"`
+}
+
+// GitFile reads versions from a manifest file committed to a git repository, rather than from tags.
+// Only the ref is fetched, with depth 1, so the repository is never fully cloned. It needs the git CLI.
+type GitFile struct {
+	// Repo is the URL of the git repository, like `https://github.com/org/repo.git`
+	Repo string `yaml:"repo"`
+	// Ref is the branch or tag to read the file at. Defaults to HEAD
+	Ref string `yaml:"ref"`
+	// Path is the path to the manifest file within the repository
+	Path string `yaml:"path"`
+	// Versions is the JSONPath expression to extract versions from the JSON or YAML manifest, like `$.versions[*]`
+	Versions string `yaml:"versions"`
+	// Pattern is the regular expression to extract versions from a manifest in any format.
+	// The first capture group is used as the version when there is one, otherwise the whole match.
+	// Used only when Versions is empty.
+	Pattern string `yaml:"pattern"`
+	// Token is used to authenticate against an HTTPS repository.
+	// It can be a secret reference like `env:GITHUB_TOKEN`, resolved via the tracker's SecretResolver.
+	// It is passed to git via envvars so that it never appears in the command line, which needs git 2.31 or later.
+	Token string `yaml:"token"`
 }
 
+// GitHubTags reads versions from the tags of a GitHub repository via `https://{host}/repos/{source}/tags`.
+// Use it for repositories that tag versions without cutting GitHub releases, instead of gitTags which needs git.
 type GitHubTags struct {
-	Host   string `yaml:"host"`
-	Source string `yaml:"source"`
+	Host string `yaml:"host"`
+	// APIPath is the path prefix of the API on Host, like `/api/v3` for GitHub Enterprise Server. Empty for github.com
+	APIPath string `yaml:"apiPath"`
+	Source  string `yaml:"source"`
+	// MaxPages caps the number of pages followed via the Link header. Defaults to DefaultMaxPages
+	MaxPages int `yaml:"maxPages"`
+	// Token authenticates requests to the GitHub API, which is required for private repositories and raises the rate limit.
+	// It works against Host, too. Use `env:GITHUB_TOKEN` to read it from an environment variable
+	Token string `yaml:"token"`
+	// Headers are sent with the requests, like `Authorization` or `X-Api-Key`.
+	// Environment variables in the values are expanded, like `Bearer $API_TOKEN`
+	Headers map[string]string `yaml:"headers"`
 }
 
+// GitHubReleases reads versions from the tag names of the GitHub releases of a repository.
+// Tags without releases are not included. Use GitHubTags for them.
 type GitHubReleases struct {
-	Host   string `yaml:"host"`
+	Host string `yaml:"host"`
+	// APIPath is the path prefix of the API on Host, like `/api/v3` for GitHub Enterprise Server. Empty for github.com
+	APIPath string `yaml:"apiPath"`
+	Source  string `yaml:"source"`
+	// MaxPages caps the number of pages followed via the Link header. Defaults to DefaultMaxPages
+	MaxPages int `yaml:"maxPages"`
+	// Token authenticates requests to the GitHub API, which is required for private repositories and raises the rate limit.
+	// It works against Host, too. Use `env:GITHUB_TOKEN` to read it from an environment variable
+	Token string `yaml:"token"`
+	// Headers are sent with the requests, like `Authorization` or `X-Api-Key`.
+	// Environment variables in the values are expanded, like `Bearer $API_TOKEN`
+	Headers map[string]string `yaml:"headers"`
+	// Versions overrides the JSONPath expression to extract versions from each page, `$[*].tag_name` by default.
+	// Use it to track release titles with `$[*].name`, or to filter releases like `$[?(@.prerelease == false)].tag_name`.
+	// Release.Description, PublishedAt and Meta are not set when overridden.
+	Versions string `yaml:"versions"`
+	// IncludeDrafts includes the draft releases, which are visible only to the users with push access
+	IncludeDrafts bool `yaml:"includeDrafts"`
+	// IncludePrereleases includes the releases marked as prereleases on GitHub.
+	// This filters on the flag set on GitHub, not on the semver prerelease part of the tag like `-rc.1`,
+	// for which see Spec.ExcludePrereleases. Neither flag is applied when Versions is overridden.
+	IncludePrereleases bool `yaml:"includePrereleases"`
+}
+
+type GitLabReleases struct {
+	// Host is the host of the GitLab instance. Defaults to gitlab.com
+	Host string `yaml:"host"`
+	// Source is the path of the project, like `group/project`
+	Source string `yaml:"source"`
+	// ProjectID is the numeric ID of the project. Used instead of Source when set
+	ProjectID string `yaml:"projectID"`
+	// Headers are sent with the requests, like `Authorization` or `X-Api-Key`.
+	// Environment variables in the values are expanded, like `Bearer $API_TOKEN`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// GitLabTags reads versions from the tags of a project on GitLab, for the projects that push tags without releases
+type GitLabTags struct {
+	// Host is the host of the GitLab instance. Defaults to gitlab.com
+	Host string `yaml:"host"`
+	// Source is the path of the project, like `group/project`
 	Source string `yaml:"source"`
+	// ProjectID is the numeric ID of the project. Used instead of Source when set
+	ProjectID string `yaml:"projectID"`
+	// Token is sent as `Authorization: Bearer <token>` header, which GitLab accepts for personal, project and group
+	// access tokens. It can be a secret reference like `env:GITLAB_TOKEN`. Use Headers for `PRIVATE-TOKEN` instead
+	Token string `yaml:"token"`
+	// Headers are sent with the requests. Environment variables in the values are expanded
+	Headers map[string]string `yaml:"headers"`
+	// MaxPages caps the number of pages followed via the Link and `X-Next-Page` headers. Defaults to DefaultMaxPages
+	MaxPages int `yaml:"maxPages"`
+}
+
+// BitbucketTags reads versions from the tags of a repository on Bitbucket Cloud
+type BitbucketTags struct {
+	// Workspace is the workspace that owns the repository, like `myorg`
+	Workspace string `yaml:"workspace"`
+	// RepoSlug is the slug of the repository, like `myrepo`
+	RepoSlug string `yaml:"repoSlug"`
+	// Token is sent as `Authorization: Bearer <token>` header, for private repositories.
+	// It can be a secret reference like `env:BITBUCKET_TOKEN`
+	Token string `yaml:"token"`
+	// Headers are sent with the requests. Environment variables in the values are expanded
+	Headers map[string]string `yaml:"headers"`
+	// MaxPages caps the number of pages followed via the `next` URL of the API. Defaults to DefaultMaxPages
+	MaxPages int `yaml:"maxPages"`
 }
 
 type DockerImageTags struct {
 	Source string `yaml:"source"`
+	// MaxPages caps the number of pages followed via the `next` URL of Docker Hub API. Defaults to DefaultMaxPages
+	MaxPages int `yaml:"maxPages"`
+	// Versions overrides the JSONPath expression to extract versions from each page, `$.results[*].name` by default.
+	// Release.PublishedAt and Meta are not set when overridden.
+	// It has no effect when DOCKER_USERNAME or DOCKER_PASSWORD is set, as tags are then listed via the registry API.
+	Versions string `yaml:"versions"`
+}
+
+// HelmChart reads versions of a Helm chart from the chart repository's index.yaml
+type HelmChart struct {
+	// Repo is the base URL of the chart repository, like `https://charts.helm.sh/stable`.
+	// The index.yaml is downloaded via go-getter, so any source supported by go-getter works
+	Repo string `yaml:"repo"`
+	// Chart is the name of the chart, like `mychart`
+	Chart string `yaml:"chart"`
+}
+
+// HelmOCI reads versions of a Helm chart stored as OCI artifacts, from the tags of the chart's repository.
+// Helm pushes every chart version as a tag, so this works without the chart repository's index.yaml.
+type HelmOCI struct {
+	// Registry is the registry host optionally followed by the namespace, like `oci://registry.example.com/charts`
+	Registry string `yaml:"registry"`
+	// Chart is the name of the chart, like `mychart`
+	Chart    string `yaml:"chart"`
+	Username string `yaml:"username"`
+	// Password can be a secret reference like `env:REGISTRY_PASSWORD`
+	Password string `yaml:"password"`
+}
+
+// ContainerImageTags reads versions from the tags of a container image in a registry that implements
+// the Docker Registry HTTP API V2, like Harbor, GHCR or a self-hosted registry
+type ContainerImageTags struct {
+	// Registry is the host of the registry, like `ghcr.io`. Docker Hub is used when empty
+	Registry string `yaml:"registry"`
+	// Repository is the repository of the image, like `myorg/myapp`
+	Repository string `yaml:"repository"`
+	Username   string `yaml:"username"`
+	// Password can be a secret reference like `env:REGISTRY_PASSWORD`
+	Password string `yaml:"password"`
+}
+
+// Scoop reads the version of an app from its manifest in a Scoop bucket, which is a git repository of JSON manifests
+type Scoop struct {
+	// Bucket is the URL of the bucket's git repository, like `https://github.com/ScoopInstaller/Main.git`
+	Bucket string `yaml:"bucket"`
+	// App is the name of the app, whose manifest is `{app}.json`
+	App string `yaml:"app"`
+	// Ref is the branch or tag of the bucket to read the manifest at. Defaults to HEAD
+	Ref string `yaml:"ref"`
+}
+
+// GoProxy reads the versions of a Go module from the module proxy.
+// All the versions are listed with `{proxy}/{module}/@v/list`.
+type GoProxy struct {
+	// Module is the module path, like `github.com/variantdev/mod`
+	Module string `yaml:"module"`
+	// Proxy is the base URL of the module proxy. Defaults to DefaultGoProxy
+	Proxy string `yaml:"proxy"`
+	// LatestOnly resolves the newest version with a single request to `@latest`, instead of listing all the versions
+	// with `@v/list`. Use it when only Latest is needed, as `@latest` also returns the publish date
+	LatestOnly bool `yaml:"latestOnly"`
+}
+
+// NpmPackage reads the versions of a package from the npm registry.
+// All the versions are read from the keys of `versions` in `{registry}/{package}`.
+type NpmPackage struct {
+	// Package is the name of the package, like `react` or `@types/node`
+	Package string `yaml:"package"`
+	// Registry is the base URL of the registry. Defaults to DefaultNpmRegistry
+	Registry string `yaml:"registry"`
+	// Headers are sent with the request, like `Authorization: Bearer $NPM_TOKEN` for private registries.
+	// Environment variables in the values are expanded
+	Headers map[string]string `yaml:"headers"`
+}
+
+// PyPIPackage reads the versions of a Python package from the keys of `releases` in `{index}/{package}/json`.
+//
+// PyPI versions are PEP 440 rather than semver, and are coerced into semver before parsing, like `1.0rc1` to `1.0-rc1`.
+// The epoch and the local version segments, like `1!` in `1!2.0` and `+ubuntu1` in `2.0+ubuntu1`, are dropped in the
+// coercion, and post releases like `2.0.post1` are as new as the release they follow. Release.Version keeps
+// the version as published. Use Spec.SkipInvalidVersions to control the versions that can't be coerced.
+type PyPIPackage struct {
+	// Package is the name of the package, like `requests`
+	Package string `yaml:"package"`
+	// Index is the base URL of the JSON API of the index. Defaults to DefaultPyPIIndex
+	Index string `yaml:"index"`
+}
+
+// MavenArtifact reads the versions of a Maven artifact from the Solr search API of the repository,
+// fetching all the pages of `{repository}/solrsearch/select?q=g:"{groupID}" AND a:"{artifactID}"&core=gav`.
+//
+// Maven versions aren't strictly semver. Versions like `31.1-jre` parse as prereleases and `1.0.0.Final` as `1.0.0-Final`,
+// and the ones that can't be parsed are skipped unless Spec.SkipInvalidVersions is disabled.
+// Use Spec.TrimVersionSuffix or Spec.VersionCapture to make them comparable.
+type MavenArtifact struct {
+	GroupID    string `yaml:"groupID"`
+	ArtifactID string `yaml:"artifactID"`
+	// Repository is the base URL of the search API. Defaults to DefaultMavenRepository
+	Repository string `yaml:"repository"`
+}
+
+// ConsulKV reads versions from values stored in Consul's KV store.
+// A single key yields one version, whereas setting Prefix reads every key under Key as a version.
+type ConsulKV struct {
+	// Address is the address of the Consul HTTP API. Defaults to http://127.0.0.1:8500
+	Address string `yaml:"address"`
+	Key     string `yaml:"key"`
+	Prefix  bool   `yaml:"prefix"`
+	// Token is the ACL token sent as the `X-Consul-Token` header. It can be a secret reference like `env:CONSUL_HTTP_TOKEN`
+	Token string `yaml:"token"`
+}
+
+// EtcdKV reads versions from values stored in etcd, via its v2 keys API.
+// A single key yields one version, whereas setting Prefix reads every key under Key as a version.
+type EtcdKV struct {
+	// Address is the address of the etcd client API. Defaults to http://127.0.0.1:2379
+	Address  string `yaml:"address"`
+	Key      string `yaml:"key"`
+	Prefix   bool   `yaml:"prefix"`
+	Username string `yaml:"username"`
+	// Password can be a secret reference like `file:/etc/etcd/password`
+	Password string `yaml:"password"`
+}
+
+// LocalFile reads versions from a local YAML or JSON file, like a versions manifest generated by another tool.
+// Unlike jsonPath, the file is read directly rather than via go-getter, so it is never cached.
+type LocalFile struct {
+	// Path is the path to the file. A relative path is resolved against the working directory of the tracker
+	Path string `yaml:"path"`
+	// Versions is the JSONPath expression to extract versions, like `$[*].version`
+	Versions string `yaml:"versions"`
+	// JQ is the jq query to extract versions, which takes precedence over Versions
+	JQ string `yaml:"jq"`
+}
+
+// S3Bucket reads versions from the keys of the objects in an S3 bucket, like `releases/1.2.3/app.tar.gz`.
+// Credentials are read from the default chain of the AWS SDK, like the envvars and `~/.aws/credentials`.
+type S3Bucket struct {
+	Bucket string `yaml:"bucket"`
+	// Prefix limits the objects to the ones whose keys start with it, like `releases/`
+	Prefix string `yaml:"prefix"`
+	// Region is the region of the bucket. Defaults to the one in the AWS config, like `AWS_REGION`
+	Region string `yaml:"region"`
+	// VersionPattern is the regexp matching the version in each key, like `app-(.+)\.tar\.gz$`.
+	// The first capture group is the version if any, or the whole match. Keys not matching it are ignored
+	VersionPattern string `yaml:"versionPattern"`
+	// Segment is the index of the slash-separated segment holding the version in each key after Prefix.
+	// Defaults to 0, so that `releases/1.2.3/app.tar.gz` yields `1.2.3` with the prefix `releases/`.
+	// Ignored when VersionPattern is set
+	Segment int `yaml:"segment"`
 }
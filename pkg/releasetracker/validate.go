@@ -0,0 +1,92 @@
+package releasetracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate returns an error unless exactly one versions source is configured.
+// The error names the sources that are set, so that it is clear which of them would otherwise be ignored.
+// Each of the fallbacks is validated likewise.
+func (s Spec) Validate() error {
+	if err := s.VersionsFrom.validate(); err != nil {
+		return err
+	}
+
+	return s.validateFallbacks()
+}
+
+// validateWithProvider is Validate for the spec whose versions source is replaced via the Provider option
+func (s Spec) validateWithProvider() error {
+	if sources := s.VersionsFrom.configuredSources(); len(sources) > 0 {
+		return fmt.Errorf("no versions source can be specified along with the provider option, but %d are set: %s", len(sources), strings.Join(sources, ", "))
+	}
+
+	return s.validateFallbacks()
+}
+
+func (s Spec) validateFallbacks() error {
+	for i, f := range s.VersionsFrom.Fallbacks {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("fallbacks[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (v VersionsFrom) validate() error {
+	sources := v.configuredSources()
+
+	switch len(sources) {
+	case 0:
+		return fmt.Errorf("no versions provider specified")
+	case 1:
+		return nil
+	}
+
+	return fmt.Errorf("only one versions source can be specified, but %d are set: %s", len(sources), strings.Join(sources, ", "))
+}
+
+// configuredSources returns the names of the configured versions sources, like `githubReleases`,
+// in the order of precedence of resolveBaseProvider
+func (v VersionsFrom) configuredSources() []string {
+	sources := []struct {
+		name string
+		set  bool
+	}{
+		{"jsonPath", v.JSONPath.Source != ""},
+		{"exec", v.Exec.Command != ""},
+		{"dockerImageTags", v.DockerImageTags.Source != ""},
+		{"containerImageTags", v.ContainerImageTags.Repository != ""},
+		{"gitTags", v.GitTags.Source != "" || v.GitTags.URL != ""},
+		{"helmChart", v.HelmChart.Repo != ""},
+		{"helmOCI", v.HelmOCI.Chart != ""},
+		{"goProxy", v.GoProxy.Module != ""},
+		{"npmPackage", v.NpmPackage.Package != ""},
+		{"pypiPackage", v.PyPIPackage.Package != ""},
+		{"mavenArtifact", v.MavenArtifact.GroupID != "" || v.MavenArtifact.ArtifactID != ""},
+		{"scoop", v.Scoop.Bucket != ""},
+		{"gitFile", v.GitFile.Repo != ""},
+		{"githubTags", v.GitHubTags.Source != ""},
+		{"bitbucketTags", v.BitbucketTags.Workspace != ""},
+		{"gitlabReleases", v.GitLabReleases.Source != "" || v.GitLabReleases.ProjectID != ""},
+		{"gitlabTags", v.GitLabTags.Source != "" || v.GitLabTags.ProjectID != ""},
+		{"githubReleases", v.GitHubReleases.Source != ""},
+		{"httpJSONPath", v.HTTPJSONPath.URL != ""},
+		{"consulKV", v.ConsulKV.Key != ""},
+		{"etcdKV", v.EtcdKV.Key != ""},
+		{"s3", v.S3.Bucket != ""},
+		{"file", v.File.Path != ""},
+	}
+
+	var names []string
+
+	for _, s := range sources {
+		if s.set {
+			names = append(names, s.name)
+		}
+	}
+
+	return names
+}
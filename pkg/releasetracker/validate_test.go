@@ -0,0 +1,51 @@
+package releasetracker
+
+import (
+	"testing"
+)
+
+func TestSpec_Validate(t *testing.T) {
+	testcases := []struct {
+		spec     Spec
+		expected string
+	}{
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{GitHubReleases: GitHubReleases{Source: "mumoshu/variant"}}},
+		},
+		{
+			spec:     Spec{},
+			expected: "no versions provider specified",
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{
+				GitHubReleases:  GitHubReleases{Source: "mumoshu/variant"},
+				DockerImageTags: DockerImageTags{Source: "mumoshu/variant"},
+			}},
+			expected: "only one versions source can be specified, but 2 are set: dockerImageTags, githubReleases",
+		},
+		{
+			spec: Spec{VersionsFrom: VersionsFrom{
+				GitHubReleases: GitHubReleases{Source: "mumoshu/variant"},
+				Fallbacks:      []VersionsFrom{{GitTags: GitTags{Source: "github.com/mumoshu/variant"}}, {}},
+			}},
+			expected: "fallbacks[1]: no versions provider specified",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := tc.spec.Validate()
+
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+
+		if got != tc.expected {
+			t.Errorf("unexpected error: expected=%q, got=%q", tc.expected, got)
+		}
+
+		if _, err := New(tc.spec); (err != nil) != (tc.expected != "") {
+			t.Errorf("unexpected error from New: %v", err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package releasetracker
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// WatchOptions customizes how Watch polls the versions source.
+type WatchOptions struct {
+	// Constraint is the semver constraint passed to Latest on every poll
+	Constraint string
+
+	// Interval is the base duration between two polls
+	Interval time.Duration
+
+	// JitterPercent randomizes each interval by up to +/- this percentage of Interval,
+	// so that many watchers started at once don't hit the upstream at the same time.
+	// 0 disables jitter.
+	JitterPercent float64
+}
+
+// Watch polls the latest release matching the constraint and calls `onChange` whenever it changes,
+// including the first successfully fetched release.
+// Poll failures are logged and retried on the next poll.
+//
+// Polls made by all the watchers of the same tracker are throttled by the limiter set via MaxConcurrentPolls.
+//
+// Watch blocks until the context is done and returns the context's error.
+// It returns immediately when the constraint is invalid.
+func (p *Tracker) Watch(ctx context.Context, opts WatchOptions, onChange func(*Release)) error {
+	if err := ValidateConstraint(opts.Constraint); err != nil {
+		return err
+	}
+
+	var current string
+
+	for {
+		latest, err := p.poll(ctx, opts.Constraint)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			p.Logger.V(1).Info("polling releases failed", "error", err)
+		} else if latest.Version != current {
+			current = latest.Version
+
+			onChange(latest)
+		}
+
+		timer := time.NewTimer(jitterInterval(opts.Interval, opts.JitterPercent, rand.Float64()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *Tracker) poll(ctx context.Context, constraint string) (*Release, error) {
+	all, err := p.pollReleases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.latestOf(constraint, all)
+}
+
+// pollReleases fetches releases once the limiter set via MaxConcurrentPolls allows
+func (p *Tracker) pollReleases(ctx context.Context) ([]*Release, error) {
+	if p.pollLimiter != nil {
+		select {
+		case p.pollLimiter <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		defer func() { <-p.pollLimiter }()
+	}
+
+	return p.GetReleasesContext(ctx)
+}
+
+// Subscribe polls releases every `interval` in the background, and sends every release matching the constraint
+// that has not been sent before. The releases found in the first poll are all sent, in ascending order of versions.
+//
+// Poll failures are sent to the error channel, and polling continues. Both channels are closed once the context is done.
+func (p *Tracker) Subscribe(ctx context.Context, constraint string, interval time.Duration) (<-chan *Release, <-chan error) {
+	releases := make(chan *Release)
+	errs := make(chan error)
+
+	go func() {
+		defer close(releases)
+		defer close(errs)
+
+		seen := map[string]bool{}
+
+		for {
+			if err := p.publishUnseen(ctx, constraint, seen, releases); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			timer := time.NewTimer(interval)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+
+	return releases, errs
+}
+
+func (p *Tracker) publishUnseen(ctx context.Context, constraint string, seen map[string]bool, ch chan<- *Release) error {
+	cons, err := parseConstraint(constraint)
+	if err != nil {
+		return err
+	}
+
+	all, err := p.pollReleases(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range all {
+		if seen[r.Version] || !cons.Check(r.Semver) {
+			continue
+		}
+
+		select {
+		case ch <- r:
+			seen[r.Version] = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// jitterInterval scales the interval by a factor within [1-percent/100, 1+percent/100], picked by `r` in [0, 1)
+func jitterInterval(interval time.Duration, percent float64, r float64) time.Duration {
+	if percent <= 0 {
+		return interval
+	}
+
+	if percent > 100 {
+		percent = 100
+	}
+
+	factor := 1 + percent/100*(2*r-1)
+
+	return time.Duration(float64(interval) * factor)
+}
@@ -0,0 +1,168 @@
+package releasetracker
+
+import (
+	"context"
+	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJitterInterval(t *testing.T) {
+	base := 10 * time.Second
+
+	min, max := 8*time.Second, 12*time.Second
+
+	seen := map[time.Duration]bool{}
+
+	for _, r := range []float64{0, 0.1, 0.5, 0.9, 0.999} {
+		d := jitterInterval(base, 20, r)
+		if d < min || d > max {
+			t.Errorf("interval out of the jitter bounds: r=%v, got=%v", r, d)
+		}
+		seen[d] = true
+	}
+
+	if len(seen) < 2 {
+		t.Errorf("interval did not vary: %v", seen)
+	}
+
+	if d := jitterInterval(base, 0, 0.9); d != base {
+		t.Errorf("unexpected interval without jitter: expected=%v, got=%v", base, d)
+	}
+}
+
+// concurrencyProvider records the maximum number of concurrent fetches
+type concurrencyProvider struct {
+	mu       sync.Mutex
+	inflight int
+	max      int
+	calls    int
+}
+
+func (p *concurrencyProvider) All() ([]*Release, error) {
+	p.mu.Lock()
+	p.inflight++
+	p.calls++
+	if p.inflight > p.max {
+		p.max = p.inflight
+	}
+	p.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	p.mu.Lock()
+	p.inflight--
+	p.mu.Unlock()
+
+	return []*Release{{Semver: semver.MustParse("1.0.0"), Version: "1.0.0"}}, nil
+}
+
+func TestTracker_Watch_MaxConcurrentPolls(t *testing.T) {
+	base := &concurrencyProvider{}
+
+	tracker := newFakeExecTracker(t, Spec{}, "0.1.0\n",
+		MaxConcurrentPolls(2),
+		ProviderMiddlewares(func(next ReleaseProvider) ReleaseProvider {
+			return base
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const watchers = 5
+
+	var changes sync.WaitGroup
+	changes.Add(watchers)
+
+	var done sync.WaitGroup
+
+	for i := 0; i < watchers; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			_ = tracker.Watch(ctx, WatchOptions{Interval: time.Hour, JitterPercent: 10}, func(r *Release) {
+				changes.Done()
+			})
+		}()
+	}
+
+	changes.Wait()
+	cancel()
+	done.Wait()
+
+	if base.calls != watchers {
+		t.Errorf("unexpected number of polls: expected=%d, got=%d", watchers, base.calls)
+	}
+
+	if base.max > 2 {
+		t.Errorf("too many concurrent polls: limit=2, got=%d", base.max)
+	}
+}
+
+func TestTracker_Subscribe(t *testing.T) {
+	var mu sync.Mutex
+
+	versions := []string{"1.0.0", "1.1.0-rc.1"}
+
+	grow := func(vs ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		versions = append(versions, vs...)
+	}
+
+	tracker := newFakeExecTracker(t, Spec{}, "", ProviderMiddlewares(func(next ReleaseProvider) ReleaseProvider {
+		return ProviderFunc(func() ([]*Release, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			var rs []*Release
+			for _, v := range versions {
+				rs = append(rs, &Release{Semver: semver.MustParse(v), Version: v})
+			}
+			return rs, nil
+		})
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	releases, errs := tracker.Subscribe(ctx, "", 5*time.Millisecond)
+
+	receive := func() string {
+		t.Helper()
+
+		select {
+		case r := <-releases:
+			return r.Version
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a release")
+		}
+		return ""
+	}
+
+	var got []string
+
+	got = append(got, receive(), receive())
+
+	grow("1.1.0", "1.2.0")
+
+	got = append(got, receive(), receive())
+
+	if d := cmp.Diff([]string{"1.0.0", "1.1.0-rc.1", "1.1.0", "1.2.0"}, got); d != "" {
+		t.Errorf("%s", d)
+	}
+
+	cancel()
+
+	for range releases {
+	}
+
+	if _, ok := <-errs; ok {
+		t.Error("the error channel should have been closed")
+	}
+}
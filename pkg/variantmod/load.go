@@ -112,6 +112,15 @@ func (m *ModuleLoader) InitModule(params confapi.ModuleParams, mod confapi.Modul
 			r.VersionsFrom.JSONPath.Description = dep.VersionsFrom.JSONPath.Description
 			r.VersionsFrom.JSONPath.Versions = dep.VersionsFrom.JSONPath.Versions
 		}
+		if dep.VersionsFrom.HTTPJSONPath.URL != nil {
+			r.VersionsFrom.HTTPJSONPath.URL, err = dep.VersionsFrom.HTTPJSONPath.URL(initialValues)
+			if err != nil {
+				return nil, err
+			}
+			r.VersionsFrom.HTTPJSONPath.Versions = dep.VersionsFrom.HTTPJSONPath.Versions
+			r.VersionsFrom.HTTPJSONPath.Headers = dep.VersionsFrom.HTTPJSONPath.Headers
+			r.VersionsFrom.HTTPJSONPath.BearerToken = dep.VersionsFrom.HTTPJSONPath.BearerToken
+		}
 
 		if dep.VersionsFrom.ValidVersionPattern != "" {
 			validVerPattern, err := regexp.Compile(dep.VersionsFrom.ValidVersionPattern)
@@ -115,6 +115,23 @@ func HCLModuleAsConfModule(mod hclconf.Module) (*confapi.Module, error) {
 				Versions:    e.Versions,
 				Description: e.Description,
 			}
+		case "http_json_path":
+			var e hclconf.HTTPJSONPath
+			if err := gohcl.DecodeBody(d.BodyForType, &hcl.EvalContext{}, &e); err != nil {
+				return nil, err
+			}
+			var bearerToken string
+			if e.BearerToken != nil {
+				bearerToken = *e.BearerToken
+			}
+			provider.HTTPJSONPath = confapi.HTTPJSONPath{
+				URL: func(_ map[string]interface{}) (string, error) {
+					return e.URL, nil
+				},
+				Versions:    e.Versions,
+				Headers:     e.Headers,
+				BearerToken: bearerToken,
+			}
 		default:
 			return nil, fmt.Errorf("dependency of type %q not implemented yet", d.Type)
 		}
@@ -2,6 +2,8 @@ package variantmod
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -120,6 +122,68 @@ provisioners:
 	}
 }
 
+func TestModuleFile_HTTPJSONPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			http.Error(w, "unexpected authorization: "+got, http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"tag": "1.0.0"}, {"tag": "1.2.0"}, {"tag": "2.0.0"}]`)
+	}))
+	defer srv.Close()
+
+	files := map[string]interface{}{
+		"/path/to/variant.mod": `
+provisioners:
+  files:
+    myapp.txt:
+      source: myapp.txt.tpl
+      arguments:
+        ver: "{{.myapp.version}}"
+
+releases:
+  myapp:
+    versionsFrom:
+      httpJSONPath:
+        url: "{{.server}}/releases"
+        versions: "$[*].tag"
+        bearerToken: secret
+
+parameters:
+  defaults:
+    server: ` + srv.URL + `
+
+dependencies:
+  myapp:
+    version: "< 2.0.0"
+`,
+		"/path/to/myapp.txt.tpl": `{{.ver}}`,
+	}
+	fs, clean, err := vfst.NewTestFS(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clean()
+	log := klogr.New()
+	klog.SetOutput(os.Stderr)
+	man, err := New(Logger(log), FS(fs), WD("/path/to"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := man.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := fs.ReadFile("/path/to/myapp.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(actual) != "1.2.0" {
+		t.Errorf("assertion failed: expected=%s, got=%s", "1.2.0", string(actual))
+	}
+}
+
 func TestModuleFile_Dependencies(t *testing.T) {
 	if testing.Verbose() {
 	}
@@ -1,11 +1,14 @@
 package vhttpget
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
 )
 
 type Option interface {
@@ -13,59 +16,200 @@ type Option interface {
 }
 
 type Opts struct {
+	Headers http.Header
+}
+
+type headerOption struct {
+	key, value string
+}
+
+func (o headerOption) Set(opts *Opts) {
+	if opts.Headers == nil {
+		opts.Headers = http.Header{}
+	}
+	opts.Headers.Add(o.key, o.value)
+}
+
+// Header adds the request header
+func Header(key, value string) Option {
+	return headerOption{key: key, value: value}
 }
 
 type Getter interface {
 	DoRequest(url string, opt ...Option) (string, error)
 }
 
+// Response is the response to a GET request, including the status and the headers
+// that are needed for e.g. following the `Link` header for pagination.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// ResponseGetter is a Getter that can also return the whole response
+type ResponseGetter interface {
+	Getter
+
+	Get(url string, opt ...Option) (*Response, error)
+}
+
+// ContextGetter is a ResponseGetter that aborts the in-flight request once the context is done
+type ContextGetter interface {
+	ResponseGetter
+
+	GetContext(ctx context.Context, url string, opt ...Option) (*Response, error)
+}
+
 type getter struct {
-	responseBodyFor func(url string, opts Opts) (io.ReadCloser, error)
+	responseFor func(ctx context.Context, url string, opts Opts) (*Response, error)
+}
+
+var _ ContextGetter = &getter{}
+
+// DefaultTimeout is the timeout of the requests made by the getter returned by New
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent is sent as the `User-Agent` header of the requests lacking it, like `variantdev-mod/v0.30.0`.
+// The version is omitted when it is unknown, as in a build of this module itself.
+var DefaultUserAgent = defaultUserAgent()
+
+const modulePath = "github.com/variantdev/mod"
+
+func defaultUserAgent() string {
+	ua := "variantdev-mod"
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ua
+	}
+
+	mods := append([]*debug.Module{&info.Main}, info.Deps...)
+
+	for _, m := range mods {
+		if m.Path == modulePath && m.Version != "" && m.Version != "(devel)" {
+			return ua + "/" + m.Version
+		}
+	}
+
+	return ua
 }
 
 func New() Getter {
+	return NewWithTimeout(DefaultTimeout)
+}
+
+// NewWithTimeout returns the getter whose request fails when no response is read within the timeout.
+// 0 means no timeout. The proxy is read from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` envvars,
+// or their lowercase versions, as http.ProxyFromEnvironment does.
+func NewWithTimeout(timeout time.Duration) Getter {
+	return NewWithClient(&http.Client{Timeout: timeout})
+}
+
+// NewWithClient returns the getter that sends the requests with the client, for using a proxy, a custom CA bundle,
+// or a client certificate via its transport.
+func NewWithClient(client *http.Client) Getter {
 	return &getter{
-		responseBodyFor: func(url string, opts Opts) (io.ReadCloser, error) {
-			res, err := http.Get(url)
-			return res.Body, err
+		responseFor: func(ctx context.Context, url string, opts Opts) (*Response, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, vs := range opts.Headers {
+				for _, v := range vs {
+					req.Header.Add(k, v)
+				}
+			}
+
+			if req.Header.Get("User-Agent") == "" {
+				req.Header.Set("User-Agent", DefaultUserAgent)
+			}
+
+			res, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+
+			bytes, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Response{StatusCode: res.StatusCode, Header: res.Header, Body: string(bytes)}, nil
 		},
 	}
 }
 
+// TestGetInput is the request expected by the tester.
+// Headers is the request headers formatted by FormatHeaders. When empty, it matches the request to the URL regardless of the headers
 type TestGetInput struct {
-	URL  string
-	Opts Opts
+	URL     string
+	Headers string
+}
+
+// FormatHeaders formats the headers into `Key: value` lines sorted by the key, for use in TestGetInput
+func FormatHeaders(h http.Header) string {
+	var lines []string
+	for k, vs := range h {
+		for _, v := range vs {
+			lines = append(lines, http.CanonicalHeaderKey(k)+": "+v)
+		}
+	}
+
+	sort.Strings(lines)
+
+	return strings.Join(lines, "\n")
 }
 
 func NewTester(expectations map[TestGetInput]string) Getter {
+	responses := map[TestGetInput]Response{}
+	for k, v := range expectations {
+		responses[k] = Response{StatusCode: http.StatusOK, Body: v}
+	}
+
+	return NewResponseTester(responses)
+}
+
+// NewResponseTester is NewTester that responds with the status and the headers, too
+func NewResponseTester(expectations map[TestGetInput]Response) ResponseGetter {
 	return &getter{
-		responseBodyFor: func(url string, opts Opts) (io.ReadCloser, error) {
-			input := TestGetInput{URL: url, Opts: opts}
+		responseFor: func(_ context.Context, url string, opts Opts) (*Response, error) {
+			input := TestGetInput{URL: url, Headers: FormatHeaders(opts.Headers)}
 			res, ok := expectations[input]
+			if !ok && input.Headers != "" {
+				res, ok = expectations[TestGetInput{URL: url}]
+			}
 			if !ok {
 				return nil, fmt.Errorf("unexpected input: %v", input)
 			}
-			r := ioutil.NopCloser(bytes.NewReader([]byte(res)))
-			return r, nil
+			if res.StatusCode == 0 {
+				res.StatusCode = http.StatusOK
+			}
+			return &res, nil
 		},
 	}
 }
 
 func (t *getter) DoRequest(url string, opt ...Option) (string, error) {
-	opts := &Opts{}
-	for _, o := range opt {
-		o.Set(opts)
-	}
-
-	res, err := t.responseBodyFor(url, *opts)
+	res, err := t.Get(url, opt...)
 	if err != nil {
 		return "", err
 	}
 
-	bytes, err := ioutil.ReadAll(res)
-	if err != nil {
-		return "", err
+	return res.Body, nil
+}
+
+func (t *getter) Get(url string, opt ...Option) (*Response, error) {
+	return t.GetContext(context.Background(), url, opt...)
+}
+
+func (t *getter) GetContext(ctx context.Context, url string, opt ...Option) (*Response, error) {
+	opts := &Opts{}
+	for _, o := range opt {
+		o.Set(opts)
 	}
 
-	return string(bytes), nil
+	return t.responseFor(ctx, url, *opts)
 }